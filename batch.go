@@ -0,0 +1,212 @@
+// inflation/batch.go
+package inflation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Batch input columns, matched case-insensitively in the header row.
+const (
+	BatchCountryColumn = "country"
+	BatchDateColumn    = "date"
+	BatchAmountColumn  = "amount"
+	BatchTargetColumn  = "target_date" // Optional
+)
+
+// BatchColumns are the columns CompareBatch appends to every input row.
+var BatchColumns = []string{"adjusted_amount", "factor", "cumulative", "annualized", "from_index", "to_index", "used_target_date", "error"}
+
+// BatchOptions configures CompareBatch.
+type BatchOptions struct {
+	Delimiter rune   // Field delimiter of input and output, ',' if zero
+	Decimal   rune   // Decimal separator of amounts, also used for the output, '.' if zero
+	Thousands rune   // Thousands separator of amounts, none if zero
+	Target    string // Target date or span for rows without one; the country's latest month if empty
+	// First month of fiscal years in dates such as FY2024, see FiscalYearSpan; 1 if zero
+	FiscalStart int
+}
+
+// BatchResult summarizes a CompareBatch run.
+type BatchResult struct {
+	Rows   int `json:"rows"`   // Data rows read
+	Failed int `json:"failed"` // Rows with an error in the error column
+}
+
+// CompareBatch adjusts every amount of a CSV file with country, date, amount
+// and optional target_date columns, see Compare, and writes the input rows to
+// w with BatchColumns appended. Dates are YYYY (annual average), YYYY-MM,
+// YYYY-MM-DD (interpolated as by DayIndexLevel) or a quarter, half or fiscal
+// year as accepted by ParseSpan (the average of its months). A row that cannot be adjusted gets its
+// error in the error column and does not stop the batch; the returned error is
+// only set if the input cannot be read or the output cannot be written.
+func (d *Data) CompareBatch(r io.Reader, w io.Writer, opts BatchOptions, queryOpts ...QueryOption) (BatchResult, error) {
+	var result BatchResult
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	writer := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+		writer.Comma = opts.Delimiter
+	}
+	if opts.Decimal == 0 {
+		opts.Decimal = '.'
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return result, fmt.Errorf("empty batch file")
+	}
+	if err != nil {
+		return result, err
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{BatchCountryColumn, BatchDateColumn, BatchAmountColumn} {
+		if _, ok := columns[name]; !ok {
+			return result, fmt.Errorf("batch file has no '%s' column", name)
+		}
+	}
+	if err := writer.Write(append(append([]string{}, header...), BatchColumns...)); err != nil {
+		return result, err
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		result.Rows++
+
+		out := make([]string, len(BatchColumns))
+		target, comparison, err := d.compareBatchRow(field(record, BatchCountryColumn), field(record, BatchDateColumn),
+			field(record, BatchAmountColumn), field(record, BatchTargetColumn), opts, queryOpts)
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			out[len(out)-1] = fmt.Sprintf("line %d: %v", line, err)
+			result.Failed++
+		} else {
+			format := func(f float64, prec int) string {
+				return strings.Replace(strconv.FormatFloat(f, 'f', prec, 64), ".", string(opts.Decimal), 1)
+			}
+			out[0] = format(comparison.Price, 2)
+			out[1] = format(comparison.Factor, 6)
+			out[2] = format(comparison.Cumulative, 4)
+			out[3] = format(comparison.Annualized, 4)
+			out[4] = format(comparison.From.Value, -1)
+			out[5] = format(comparison.To.Value, -1)
+			out[6] = target
+		}
+		if err := writer.Write(append(record, out...)); err != nil {
+			return result, err
+		}
+	}
+	writer.Flush()
+	return result, writer.Error()
+}
+
+// compareBatchRow adjusts the amount of one batch row and returns the target date used.
+func (d *Data) compareBatchRow(country, date, amount, target string, opts BatchOptions, queryOpts []QueryOption) (string, Comparison, error) {
+	if country == "" {
+		return "", Comparison{}, fmt.Errorf("missing country")
+	}
+	from, err := parseBatchDate(date, opts.FiscalStart)
+	if err != nil {
+		return "", Comparison{}, err
+	}
+	value, err := ParseNumber(amount, opts.Decimal, opts.Thousands)
+	if err != nil {
+		return "", Comparison{}, fmt.Errorf("invalid amount '%s'", amount)
+	}
+
+	c, s, err := d.countrySeries(country, queryOpts...)
+	if err != nil {
+		return "", Comparison{}, err
+	}
+	if target == "" {
+		target = opts.Target
+	}
+	if target == "" {
+		if s.Len() == 0 {
+			return "", Comparison{}, fmt.Errorf("no inflation data available for country '%s'", country)
+		}
+		target = s.observed.String()
+	}
+	to, err := parseBatchDate(target, opts.FiscalStart)
+	if err != nil {
+		return "", Comparison{}, err
+	}
+
+	fromValue, err := s.dateValue(country, from)
+	if err != nil {
+		return "", Comparison{}, err
+	}
+	toValue, err := s.dateValue(country, to)
+	if err != nil {
+		return "", Comparison{}, err
+	}
+	comparison, err := newComparison(c, newQuery(queryOpts).series, fromValue, toValue, value)
+	comparison.Projection = s.projectionName(fromValue, toValue)
+	return to.String(), comparison, err
+}
+
+// batchDate is a date of a batch row or amount: a day or a span.
+type batchDate struct {
+	day  time.Time // Set for a day in YYYY-MM-DD format
+	span Span
+}
+
+// parseBatchDate parses a day in YYYY-MM-DD format, whose level is
+// interpolated as by DayIndexLevel, or a span as accepted by ParseSpan.
+func parseBatchDate(date string, fiscalStart int) (batchDate, error) {
+	day, daily, err := ParseDay(date)
+	if err != nil {
+		return batchDate{}, err
+	}
+	if daily {
+		return batchDate{day: day}, nil
+	}
+	span, err := ParseSpan(date, fiscalStart)
+	if err != nil {
+		return batchDate{}, fmt.Errorf("invalid date '%s'", date)
+	}
+	return batchDate{span: span}, nil
+}
+
+func (b batchDate) String() string {
+	if !b.day.IsZero() {
+		return b.day.Format("2006-01-02")
+	}
+	return b.span.String()
+}
+
+// dateValue returns the index level of a day or span.
+func (s indexSeries) dateValue(country string, date batchDate) (IndexValue, error) {
+	if !date.day.IsZero() {
+		return s.dayValue(country, date.day)
+	}
+	return s.spanValue(country, date.span)
+}
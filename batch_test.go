@@ -0,0 +1,79 @@
+// batch_test.go
+package inflation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareBatch(t *testing.T) {
+	data := createTestData()
+	input := strings.Join([]string{
+		"invoice,Country,date,amount,target_date",
+		"A1,US,2015,100,2016",
+		"A2,US,2015-01-17,10,",
+		"A3,DE,2015-01,100,2018-12",
+		"A4,ES,2015,100,2016",
+		"A5,US,2015-13,100,2016",
+		"A6,US,2015,abc,2016",
+		"A7,US,2015-02-30,100,2016",
+		"",
+	}, "\n")
+
+	var out bytes.Buffer
+	result, err := data.CompareBatch(strings.NewReader(input), &out, BatchOptions{Target: "2016-01"})
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if result.Rows != 7 || result.Failed != 4 {
+		t.Errorf("Expected 7 rows with 4 failures, but got %+v", result)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("Did not expect error reading output, but got: %v", err)
+	}
+	if len(records) != 8 {
+		t.Fatalf("Expected header and 7 rows, but got %d records", len(records))
+	}
+	header := records[0]
+	if header[0] != "invoice" || len(header) != 5+len(BatchColumns) {
+		t.Errorf("Expected input columns followed by the batch columns, but got %v", header)
+	}
+
+	column := func(row []string, name string) string {
+		for i, h := range header {
+			if h == name {
+				return row[i]
+			}
+		}
+		t.Fatalf("Column %s not found", name)
+		return ""
+	}
+	// 2015 average 0.2, 2016 average 0.25
+	if got := column(records[1], "adjusted_amount"); got != "125.00" {
+		t.Errorf("Expected 125.00 for A1, but got %s", got)
+	}
+	// A day is interpolated as by compare, the default target 2016-01 is 0.15
+	day, _ := data.DayIndexLevel("US", time.Date(2015, 1, 17, 0, 0, 0, 0, time.UTC))
+	expected := strconv.FormatFloat(10*0.15/day.Value, 'f', 2, 64)
+	if got := column(records[2], "adjusted_amount"); got != expected || column(records[2], "used_target_date") != "2016-01" {
+		t.Errorf("Expected %s at 2016-01 for A2, but got %s at %s", expected, got, column(records[2], "used_target_date"))
+	}
+	if column(records[3], "error") != "" {
+		t.Errorf("Did not expect error for A3, but got: %s", column(records[3], "error"))
+	}
+	for _, row := range records[4:] {
+		if !strings.HasPrefix(column(row, "error"), "line ") || column(row, "adjusted_amount") != "" {
+			t.Errorf("Expected a line error for %s, but got %v", row[0], row)
+		}
+	}
+
+	if _, err := data.CompareBatch(strings.NewReader("country,amount\nUS,1\n"), &out, BatchOptions{}); err == nil {
+		t.Errorf("Expected error for missing date column, but got none")
+	}
+}
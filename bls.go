@@ -0,0 +1,250 @@
+// inflation/bls.go
+package inflation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// BLSHeadline is the series id of the CPI-U, U.S. city average, all items, not seasonally adjusted.
+const BLSHeadline = "CUUR0000SA0"
+
+// BLSBaseYear is the base year of BLS CPI series, which are published on a
+// 1982-84=100 base. Like Data.RebaseSpan, it names the year the base ends in.
+const BLSBaseYear = 1984
+
+// ReadBLSFile reads a BLS time-series file: either a flat file of the
+// cu.data.* layout or a response of the BLS v2 API.
+func ReadBLSFile(path string) ([]ImportedSeries, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseBLS(file)
+}
+
+// ParseBLS reads BLS time-series data, detecting v2 API JSON or the flat file layout from the content.
+func ParseBLS(r io.Reader) ([]ImportedSeries, error) {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return nil, fmt.Errorf("empty BLS file")
+		}
+		if b[0] == ' ' || b[0] == '\t' || b[0] == '\r' || b[0] == '\n' {
+			br.ReadByte()
+			continue
+		}
+		if b[0] == '{' {
+			return ParseBLSJSON(br)
+		}
+		return ParseBLSFlatFile(br)
+	}
+}
+
+// blsSeries collects the observations of BLS series in the order they appear.
+type blsSeries struct {
+	byID  map[string]*ImportedSeries
+	order []string
+}
+
+// add records a value for a BLS period code. Only monthly periods (M01-M12)
+// are kept; annual averages (M13) and semiannual periods are skipped.
+func (b *blsSeries) add(seriesID string, yearStr string, periodCode string, valueStr string) error {
+	in, exists := b.byID[seriesID]
+	if !exists {
+		in = &ImportedSeries{
+			Key:        seriesID,
+			Area:       "US",
+			Name:       "United States",
+			SubIndex:   blsSubIndex(seriesID),
+			Dimensions: map[string]string{"SERIES_ID": seriesID},
+		}
+		if strings.HasPrefix(seriesID, "CU") {
+			in.BaseYear = BLSBaseYear
+		}
+		if area := blsArea(seriesID); area != "" {
+			in.Area = "US/" + area
+			in.Name = blsAreaNames[area]
+		}
+		if b.byID == nil {
+			b.byID = make(map[string]*ImportedSeries)
+		}
+		b.byID[seriesID] = in
+		b.order = append(b.order, seriesID)
+	}
+
+	if len(periodCode) != 3 || periodCode[0] != 'M' || periodCode == "M13" {
+		return nil
+	}
+	month, err := strconv.Atoi(periodCode[1:])
+	if err != nil || month < 1 || month > 12 {
+		return fmt.Errorf("invalid period '%s' for series %s", periodCode, seriesID)
+	}
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		return fmt.Errorf("invalid year '%s' for series %s", yearStr, seriesID)
+	}
+	if valueStr == "" || valueStr == "-" {
+		return nil // Missing observation
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return fmt.Errorf("invalid value '%s' for series %s in %d-%02d", valueStr, seriesID, year, month)
+	}
+	in.Series = append(in.Series, Observation{Period: Period{Year: year, Month: month}, Value: value})
+	return nil
+}
+
+func (b *blsSeries) result() []ImportedSeries {
+	result := make([]ImportedSeries, 0, len(b.order))
+	for _, id := range b.order {
+		in := b.byID[id]
+		in.Series = sortSeries(in.Series)
+		result = append(result, *in)
+	}
+	return result
+}
+
+// ParseBLSFlatFile reads a BLS flat file such as cu.data.0.Current: tab separated
+// columns series_id, year, period, value and footnote_codes, padded with spaces.
+func ParseBLSFlatFile(r io.Reader) ([]ImportedSeries, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var series blsSeries
+	header := true
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if header {
+			header = false
+			if len(fields) < 4 || fields[0] != "series_id" || fields[1] != "year" || fields[2] != "period" || fields[3] != "value" {
+				return nil, fmt.Errorf("BLS flat file must start with the header 'series_id year period value'")
+			}
+			continue
+		}
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("invalid BLS record on line %d: %s", line, text)
+		}
+		err := series.add(fields[0], fields[1], fields[2], fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if header {
+		return nil, fmt.Errorf("BLS flat file is empty")
+	}
+	return series.result(), nil
+}
+
+// blsResponse is the subset of a BLS v2 API response used for importing.
+type blsResponse struct {
+	Status  string   `json:"status"`
+	Message []string `json:"message"`
+	Results struct {
+		Series []struct {
+			SeriesID string `json:"seriesID"`
+			Data     []struct {
+				Year   string `json:"year"`
+				Period string `json:"period"`
+				Value  string `json:"value"`
+			} `json:"data"`
+		} `json:"series"`
+	} `json:"Results"`
+}
+
+// ParseBLSJSON reads a response of the BLS public data API v2 (timeseries/data).
+func ParseBLSJSON(r io.Reader) ([]ImportedSeries, error) {
+	var resp blsResponse
+	err := json.NewDecoder(r).Decode(&resp)
+	if err != nil {
+		return nil, fmt.Errorf("error reading BLS API response: %v", err)
+	}
+	if resp.Status != "REQUEST_SUCCEEDED" {
+		return nil, fmt.Errorf("BLS API request failed: %s %s", resp.Status, strings.Join(resp.Message, "; "))
+	}
+
+	var series blsSeries
+	for _, s := range resp.Results.Series {
+		if len(s.Data) == 0 {
+			series.add(s.SeriesID, "", "", "") // Keep empty series visible
+		}
+		for _, d := range s.Data {
+			err := series.add(s.SeriesID, d.Year, d.Period, strings.TrimSpace(d.Value))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return series.result(), nil
+}
+
+// blsItems maps BLS CPI item codes to the sub-index codes used for other countries.
+var blsItems = map[string]string{
+	"SA0":    "",
+	"SAF1":   "FOOD",
+	"SA0E":   "NRG",
+	"SAS":    "SERV",
+	"SAC":    "GD",
+	"SAH":    "CP04",
+	"SAA":    "CP03",
+	"SAM":    "CP06",
+	"SAT":    "CP07",
+	"SA0L1E": "TOT_X_NRG_FOOD",
+}
+
+// blsAreaNames holds the names of the BLS CPI areas imported as regions of the US.
+// Other areas, such as metropolitan areas, are named after their code.
+var blsAreaNames = map[string]string{
+	"0100": "Northeast",
+	"0200": "Midwest",
+	"0300": "South",
+	"0400": "West",
+	"S11A": "Boston-Cambridge-Newton",
+	"S12A": "New York-Newark-Jersey City",
+	"S23A": "Chicago-Naperville-Elgin",
+	"S35B": "Miami-Fort Lauderdale-West Palm Beach",
+	"S49A": "Los Angeles-Long Beach-Anaheim",
+	"S49B": "San Francisco-Oakland-Hayward",
+}
+
+// blsArea returns the area code of a CPI series id such as CUUR0100SA0,
+// or "" for the U.S. city average.
+func blsArea(seriesID string) string {
+	if len(seriesID) <= 8 || !strings.HasPrefix(seriesID, "CU") || seriesID[4:8] == "0000" {
+		return ""
+	}
+	return seriesID[4:8]
+}
+
+// blsSubIndex returns the sub-index code for a CPI series id such as
+// CUUR0000SAF1: the item code after the prefix, area and periodicity,
+// mapped with blsItems, or "" for the headline index.
+func blsSubIndex(seriesID string) string {
+	if len(seriesID) <= 8 || !strings.HasPrefix(seriesID, "CU") {
+		return ""
+	}
+	item := seriesID[8:]
+	if code, ok := blsItems[item]; ok {
+		return code
+	}
+	return item
+}
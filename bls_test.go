@@ -0,0 +1,102 @@
+// bls_test.go
+package inflation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadBLSFile(t *testing.T) {
+	tests := []struct {
+		path           string
+		expectedSeries int
+	}{
+		{"testdata/bls_cu.data.txt", 2},
+		{"testdata/bls_api.json", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			imported, err := ReadBLSFile(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to read BLS file: %v", err)
+			}
+			if len(imported) != tt.expectedSeries {
+				t.Fatalf("Expected %d series, got %d", tt.expectedSeries, len(imported))
+			}
+
+			cpi := imported[0]
+			if cpi.Key != BLSHeadline || cpi.Area != "US" {
+				t.Errorf("Unexpected series %s for area %s", cpi.Key, cpi.Area)
+			}
+			// 12 months of 2023 and 3 of 2024; M13 annual average and S01 are skipped
+			if cpi.Series.Len() != 15 {
+				t.Errorf("Expected 15 monthly observations, got %d", cpi.Series.Len())
+			}
+			first, _ := cpi.Series.First()
+			last, _ := cpi.Series.Last()
+			if first.Period != (Period{Year: 2023, Month: 1}) || !floatsAlmostEqual(first.Value, 299.170) {
+				t.Errorf("Expected first observation 2023-01 = 299.170, got %s = %.3f", first.Period, first.Value)
+			}
+			if last.Period != (Period{Year: 2024, Month: 3}) || !floatsAlmostEqual(last.Value, 312.332) {
+				t.Errorf("Expected last observation 2024-03 = 312.332, got %s = %.3f", last.Period, last.Value)
+			}
+		})
+	}
+}
+
+func TestParseBLS_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Failed API request", `{"status":"REQUEST_NOT_PROCESSED","message":["Daily threshold exceeded"],"Results":{}}`},
+		{"Missing header", "CUUR0000SA0\t2023\tM01\t299.170\t\n"},
+		{"Invalid month", "series_id\tyear\tperiod\tvalue\tfootnote_codes\nCUUR0000SA0\t2023\tM14\t299.170\t\n"},
+		{"Invalid value", "series_id\tyear\tperiod\tvalue\tfootnote_codes\nCUUR0000SA0\t2023\tM01\tn/a\t\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBLS(strings.NewReader(tt.input))
+			if err == nil {
+				t.Errorf("Expected error for %s, but got none", tt.name)
+			}
+		})
+	}
+}
+
+func TestMergeSeries_BLS(t *testing.T) {
+	data := createTestData()
+	imported, err := ReadBLSFile("testdata/bls_api.json")
+	if err != nil {
+		t.Fatalf("Failed to read BLS file: %v", err)
+	}
+
+	if imported[0].BaseYear != BLSBaseYear {
+		t.Errorf("Expected base year %d, got %d", BLSBaseYear, imported[0].BaseYear)
+	}
+	// The test data is on a 2015 base
+	if _, err := data.MergeSeries(imported[0]); err == nil {
+		t.Errorf("Expected error for a different base year, but got none")
+	}
+	report, err := data.MergeSeries(imported[0], WithForce())
+	if err != nil {
+		t.Fatalf("Failed to merge BLS series: %v", err)
+	}
+	if report.Country != "United States" || report.Created || len(report.Added) != 15 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	// A revised value is reported as changed
+	revised := imported[0]
+	revised.Series = append(Series{}, revised.Series...)
+	revised.Series[14].Value = 312.5
+	report, err = data.MergeSeries(revised)
+	if err != nil {
+		t.Fatalf("Failed to merge revised BLS series: %v", err)
+	}
+	if len(report.Changed) != 1 || report.Changed[0] != (Period{Year: 2024, Month: 3}) || report.Unchanged != 14 {
+		t.Errorf("Expected only 2024-03 to change, got %+v", report)
+	}
+}
@@ -0,0 +1,178 @@
+// inflation/cache.go
+package inflation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCacheTTL is how long a cached inflation list is used before it is revalidated.
+const DefaultCacheTTL = 24 * time.Hour
+
+// Cache stores inflation lists downloaded from URLs on disk, keyed by URL.
+// Entries younger than TTL are used as they are; older entries are revalidated
+// with a conditional request (ETag / Last-Modified). If the server cannot be
+// reached or fails, a stale entry is used instead of failing, but not when the
+// context is canceled or its deadline has passed.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// cacheEntry is the metadata stored next to a cached inflation list.
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// NewCache returns a cache storing its entries in dir.
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, TTL: ttl}
+}
+
+// DefaultCache returns a cache in the "inflation" folder of the user cache directory.
+func DefaultCache() (*Cache, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewCache(filepath.Join(dir, "inflation"), DefaultCacheTTL), nil
+}
+
+// Fetch returns the body stored at url, using the cache where possible.
+func (c *Cache) Fetch(url string) ([]byte, error) {
+	return c.FetchContext(context.Background(), url)
+}
+
+// FetchContext is like Fetch, configured by the context and options for the download.
+func (c *Cache) FetchContext(ctx context.Context, url string, opts ...LoadOption) ([]byte, error) {
+	return c.fetch(ctx, url, newLoadConfig(opts))
+}
+
+func (c *Cache) fetch(ctx context.Context, url string, cfg *loadConfig) ([]byte, error) {
+	entry, body, cached := c.read(url)
+	if cached && time.Since(entry.FetchedAt) < c.TTL {
+		return body, nil
+	}
+
+	header := http.Header{}
+	if cached {
+		if entry.ETag != "" {
+			header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := cfg.get(ctx, url, header)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if cached && !errors.Is(err, ErrBodyTooLarge) {
+			return body, nil // Offline: use the stale copy
+		}
+		return nil, err
+	}
+
+	switch {
+	case resp.statusCode == http.StatusNotModified && cached:
+		entry.FetchedAt = time.Now()
+		return body, c.writeEntry(url, entry)
+	case resp.statusCode == http.StatusOK:
+		if !json.Valid(resp.body) {
+			if cached {
+				return body, nil
+			}
+			return nil, errors.New("inflation data from URL is not valid JSON")
+		}
+		entry = cacheEntry{
+			URL:          url,
+			ETag:         resp.header.Get("ETag"),
+			LastModified: resp.header.Get("Last-Modified"),
+			FetchedAt:    time.Now(),
+		}
+		return resp.body, c.write(url, entry, resp.body)
+	case cached:
+		return body, nil // Server error: use the stale copy
+	default:
+		return nil, &HTTPError{URL: url, StatusCode: resp.statusCode, Status: resp.status}
+	}
+}
+
+// paths returns the data and metadata file names for a URL.
+func (c *Cache) paths(url string) (string, string) {
+	sum := sha256.Sum256([]byte(url))
+	key := hex.EncodeToString(sum[:])
+	return filepath.Join(c.Dir, key+".json"), filepath.Join(c.Dir, key+".meta.json")
+}
+
+// read returns the cached entry for a URL, if there is a usable one.
+func (c *Cache) read(url string) (cacheEntry, []byte, bool) {
+	var entry cacheEntry
+	dataPath, metaPath := c.paths(url)
+
+	meta, err := os.ReadFile(metaPath)
+	if err != nil || json.Unmarshal(meta, &entry) != nil || entry.URL != url {
+		return entry, nil, false
+	}
+	body, err := os.ReadFile(dataPath)
+	if err != nil {
+		return entry, nil, false
+	}
+	return entry, body, true
+}
+
+// write stores the body and metadata for a URL.
+func (c *Cache) write(url string, entry cacheEntry, body []byte) error {
+	err := os.MkdirAll(c.Dir, 0755)
+	if err != nil {
+		return err
+	}
+	dataPath, _ := c.paths(url)
+	err = writeFileAtomic(dataPath, body)
+	if err != nil {
+		return err
+	}
+	return c.writeEntry(url, entry)
+}
+
+// writeEntry stores the metadata for a URL.
+func (c *Cache) writeEntry(url string, entry cacheEntry) error {
+	meta, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, metaPath := c.paths(url)
+	return writeFileAtomic(metaPath, meta)
+}
+
+// writeFileAtomic writes a file through a temporary file so readers never see partial content.
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
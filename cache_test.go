@@ -0,0 +1,153 @@
+// cache_test.go
+package inflation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestServer serves the test data with an ETag and counts requests and 304 responses.
+func newTestServer(t *testing.T) (*httptest.Server, *int32, *int32) {
+	t.Helper()
+	body, err := json.Marshal(createTestData())
+	if err != nil {
+		t.Fatalf("Failed to marshal mock data: %v", err)
+	}
+
+	var requests, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Tue, 28 Jan 2025 10:00:00 GMT")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests, &notModified
+}
+
+func TestCache_FreshEntryIsReused(t *testing.T) {
+	server, requests, _ := newTestServer(t)
+	cache := NewCache(t.TempDir(), time.Hour)
+
+	for i := 0; i < 3; i++ {
+		data, err := LoadInflationDataCached(server.URL, cache)
+		if err != nil {
+			t.Fatalf("Failed to load inflation data through cache: %v", err)
+		}
+		if len(data.Countries) != 2 {
+			t.Errorf("Expected 2 countries, got %d", len(data.Countries))
+		}
+	}
+
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("Expected 1 request while the entry is fresh, got %d", got)
+	}
+}
+
+func TestCache_StaleEntryIsRevalidated(t *testing.T) {
+	server, requests, notModified := newTestServer(t)
+	cache := NewCache(t.TempDir(), 0) // Every use revalidates
+
+	for i := 0; i < 3; i++ {
+		_, err := LoadInflationDataCached(server.URL, cache)
+		if err != nil {
+			t.Fatalf("Failed to load inflation data through cache: %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(requests); got != 3 {
+		t.Errorf("Expected 3 requests, got %d", got)
+	}
+	if got := atomic.LoadInt32(notModified); got != 2 {
+		t.Errorf("Expected 2 conditional requests answered with 304, got %d", got)
+	}
+}
+
+func TestCache_OfflineFallback(t *testing.T) {
+	server, _, _ := newTestServer(t)
+	cache := NewCache(t.TempDir(), 0)
+
+	_, err := LoadInflationDataCached(server.URL, cache)
+	if err != nil {
+		t.Fatalf("Failed to load inflation data through cache: %v", err)
+	}
+
+	url := server.URL
+	server.Close()
+
+	data, err := LoadInflationDataContext(context.Background(), url, WithCache(cache), WithRetries(0, 0))
+	if err != nil {
+		t.Fatalf("Expected stale copy when offline, but got: %v", err)
+	}
+	if len(data.Countries) != 2 {
+		t.Errorf("Expected 2 countries from stale copy, got %d", len(data.Countries))
+	}
+
+	// Without a cached copy the error is reported
+	_, err = LoadInflationDataContext(context.Background(), url, WithCache(NewCache(t.TempDir(), 0)), WithRetries(0, 0))
+	if err == nil {
+		t.Errorf("Expected error when offline without a cached copy, but got none")
+	}
+}
+
+func TestCache_CanceledContext(t *testing.T) {
+	server, _, _ := newTestServer(t)
+	cache := NewCache(t.TempDir(), 0)
+
+	_, err := LoadInflationDataCached(server.URL, cache)
+	if err != nil {
+		t.Fatalf("Failed to load inflation data through cache: %v", err)
+	}
+
+	// A canceled request is reported instead of using the stale copy
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = LoadInflationDataContext(ctx, server.URL, WithCache(cache))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestCache_ServerErrorFallback(t *testing.T) {
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(createTestData())
+	}))
+	defer server.Close()
+	cache := NewCache(t.TempDir(), 0)
+
+	_, err := LoadInflationDataCached(server.URL, cache)
+	if err != nil {
+		t.Fatalf("Failed to load inflation data through cache: %v", err)
+	}
+
+	failing = true
+	_, err = LoadInflationDataContext(context.Background(), server.URL, WithCache(cache), WithRetries(0, 0))
+	if err != nil {
+		t.Errorf("Expected stale copy on server error, but got: %v", err)
+	}
+}
+
+func TestCache_KeyedByURL(t *testing.T) {
+	cache := NewCache(t.TempDir(), time.Hour)
+	dataA, _ := cache.paths("https://example.com/a.json")
+	dataB, _ := cache.paths("https://example.com/b.json")
+	if dataA == dataB {
+		t.Errorf("Expected different cache files for different URLs, got %s", dataA)
+	}
+}
@@ -0,0 +1,142 @@
+// inflation/chain.go
+package inflation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RateKind identifies how a series of percentage changes is measured.
+type RateKind int
+
+const (
+	// MonthOverMonthRate compares each month with the month before.
+	MonthOverMonthRate RateKind = iota + 1
+	// YearOverYearRate compares each month with the same month of the previous year.
+	YearOverYearRate
+)
+
+// ParseRateKind parses "mom" or "yoy".
+func ParseRateKind(s string) (RateKind, error) {
+	switch strings.ToLower(s) {
+	case "mom", "month-over-month":
+		return MonthOverMonthRate, nil
+	case "yoy", "year-over-year":
+		return YearOverYearRate, nil
+	default:
+		return 0, fmt.Errorf("unknown rate kind '%s' (expected mom or yoy)", s)
+	}
+}
+
+// lag returns the number of months a rate of this kind compares against.
+func (k RateKind) lag() int {
+	if k == YearOverYearRate {
+		return 12
+	}
+	return 1
+}
+
+func (k RateKind) String() string {
+	switch k {
+	case MonthOverMonthRate:
+		return "month-over-month"
+	case YearOverYearRate:
+		return "year-over-year"
+	default:
+		return fmt.Sprintf("RateKind(%d)", int(k))
+	}
+}
+
+// RatesFromIndex converts an index series to percentage changes of the given
+// kind. Months without a comparison value are left out.
+func RatesFromIndex(index Series, kind RateKind) (Series, error) {
+	var rates Series
+	for _, o := range index {
+		prev, ok := index.Lookup(o.Period.AddMonths(-kind.lag()))
+		if !ok {
+			continue
+		}
+		rate, err := percentChange(prev, o.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", o.Period, err)
+		}
+		rates = append(rates, Observation{Period: o.Period, Value: rate})
+	}
+	return rates, nil
+}
+
+// ChainRates builds an index from percentage changes, starting from known
+// index values in seed. Month-over-month rates need the seed value for the
+// month before the first rate; year-over-year rates need the seed values for
+// the twelve months before it. The result contains the seed values followed
+// by the chained values, and the rates must have no gaps.
+func ChainRates(rates Series, kind RateKind, seed Series) (Series, error) {
+	first, ok := rates.First()
+	if !ok {
+		return nil, fmt.Errorf("no rates to chain")
+	}
+	lag := kind.lag()
+	seedStart := first.Period.AddMonths(-lag)
+
+	index := append(Series{}, seed.Range(seedStart, first.Period.AddMonths(-1))...)
+	if index.Len() != lag {
+		return nil, fmt.Errorf("chaining %s rates from %s needs index values from %s to %s",
+			kind, first.Period, seedStart, first.Period.AddMonths(-1))
+	}
+
+	for i, o := range rates {
+		if i > 0 && rates[i-1].Period.AddMonths(1) != o.Period {
+			return nil, fmt.Errorf("rates are missing between %s and %s", rates[i-1].Period, o.Period)
+		}
+		if o.Value <= -100 {
+			return nil, fmt.Errorf("invalid rate %.2f%% for %s", o.Value, o.Period)
+		}
+		prev := index[len(index)-lag].Value
+		index = append(index, Observation{Period: o.Period, Value: prev * (1 + o.Value/100)})
+	}
+	return index, nil
+}
+
+// IndexFromRates builds an index from percentage changes so that the index
+// equals anchorValue at the anchor period.
+//
+// Month-over-month rates fully determine the index up to that scale; the
+// result also covers the month before the first rate. Year-over-year rates
+// only relate each month to the same month a year earlier, so the twelve
+// months before the first rate are assumed to be flat. The rates are
+// preserved, but the seasonal pattern of that first year is lost; use
+// ChainRates with known index values for an exact result.
+func IndexFromRates(rates Series, kind RateKind, anchor Period, anchorValue float64) (Series, error) {
+	return IndexFromRatesSpan(rates, kind, MonthSpan(anchor.Year, anchor.Month), anchorValue)
+}
+
+// IndexFromRatesSpan builds an index from percentage changes like
+// IndexFromRates so that the average of the months of the anchor span, such
+// as a year or quarter, equals anchorValue.
+func IndexFromRatesSpan(rates Series, kind RateKind, anchor Span, anchorValue float64) (Series, error) {
+	first, ok := rates.First()
+	if !ok {
+		return nil, fmt.Errorf("no rates to chain")
+	}
+
+	var seed Series
+	for p := first.Period.AddMonths(-kind.lag()); p.Before(first.Period); p = p.AddMonths(1) {
+		seed = append(seed, Observation{Period: p, Value: 1})
+	}
+	index, err := ChainRates(rates, kind, seed)
+	if err != nil {
+		return nil, err
+	}
+	if kind == YearOverYearRate {
+		index = index[kind.lag():] // Drop the assumed values
+	}
+
+	months := index.Range(anchor.From, anchor.To)
+	if months.Len() != anchor.Months() {
+		first, _ := index.First()
+		last, _ := index.Last()
+		return nil, fmt.Errorf("anchor %s is outside the chained index from %s to %s", anchor, first.Period, last.Period)
+	}
+	value, _ := months.Average()
+	return index.Scale(anchorValue / value), nil
+}
@@ -0,0 +1,140 @@
+// chain_test.go
+package inflation
+
+import (
+	"math"
+	"testing"
+)
+
+// defaultSeries returns the series of a country from the embedded data.
+func defaultSeries(t *testing.T, country string) Series {
+	t.Helper()
+	data, err := Default()
+	if err != nil {
+		t.Fatalf("Failed to load embedded default data: %v", err)
+	}
+	_, s, err := data.countrySeries(country)
+	if err != nil {
+		t.Fatalf("Failed to get series for '%s': %v", country, err)
+	}
+	return s.Series
+}
+
+// seriesAlmostEqual reports the first period where two series differ, if any.
+func seriesAlmostEqual(t *testing.T, expected, got Series) {
+	t.Helper()
+	if expected.Len() != got.Len() {
+		t.Fatalf("Expected %d observations, got %d", expected.Len(), got.Len())
+	}
+	for i := range expected {
+		if expected[i].Period != got[i].Period || math.Abs(expected[i].Value-got[i].Value) > 1e-9 {
+			t.Fatalf("Expected %s = %.6f, got %s = %.6f", expected[i].Period, expected[i].Value, got[i].Period, got[i].Value)
+		}
+	}
+}
+
+func TestRoundTrip_MonthOverMonth(t *testing.T) {
+	index := defaultSeries(t, "US")
+
+	rates, err := RatesFromIndex(index, MonthOverMonthRate)
+	if err != nil {
+		t.Fatalf("Failed to compute rates: %v", err)
+	}
+	if rates.Len() != index.Len()-1 {
+		t.Errorf("Expected %d rates, got %d", index.Len()-1, rates.Len())
+	}
+
+	// Anchoring at the first observation reproduces the original index
+	first, _ := index.First()
+	chained, err := IndexFromRates(rates, MonthOverMonthRate, first.Period, first.Value)
+	if err != nil {
+		t.Fatalf("Failed to chain rates: %v", err)
+	}
+	seriesAlmostEqual(t, index, chained)
+
+	// Anchoring at any other observation does as well
+	anchor := index[100]
+	chained, err = IndexFromRates(rates, MonthOverMonthRate, anchor.Period, anchor.Value)
+	if err != nil {
+		t.Fatalf("Failed to chain rates: %v", err)
+	}
+	seriesAlmostEqual(t, index, chained)
+
+	// Anchoring at the average of a year scales the index so that it averages the value
+	year := YearSpan(2020)
+	average, _ := index.Range(year.From, year.To).Average()
+	chained, err = IndexFromRatesSpan(rates, MonthOverMonthRate, year, average)
+	if err != nil {
+		t.Fatalf("Failed to chain rates: %v", err)
+	}
+	seriesAlmostEqual(t, index, chained)
+}
+
+func TestRoundTrip_YearOverYear(t *testing.T) {
+	index := defaultSeries(t, "GR")
+
+	rates, err := RatesFromIndex(index, YearOverYearRate)
+	if err != nil {
+		t.Fatalf("Failed to compute rates: %v", err)
+	}
+
+	// With the first year as seed the original index is reproduced exactly
+	chained, err := ChainRates(rates, YearOverYearRate, index[:12])
+	if err != nil {
+		t.Fatalf("Failed to chain rates: %v", err)
+	}
+	seriesAlmostEqual(t, index, chained)
+
+	// Anchored without a seed, the year-over-year rates are still preserved
+	anchor := index[len(index)-1]
+	anchored, err := IndexFromRates(rates, YearOverYearRate, anchor.Period, anchor.Value)
+	if err != nil {
+		t.Fatalf("Failed to chain rates: %v", err)
+	}
+	value, _ := anchored.Lookup(anchor.Period)
+	if !floatsAlmostEqual(value, anchor.Value) {
+		t.Errorf("Expected %.6f at anchor %s, got %.6f", anchor.Value, anchor.Period, value)
+	}
+	again, err := RatesFromIndex(anchored, YearOverYearRate)
+	if err != nil {
+		t.Fatalf("Failed to compute rates: %v", err)
+	}
+	for _, o := range again {
+		expected, _ := rates.Lookup(o.Period)
+		if !floatsAlmostEqual(o.Value, expected) {
+			t.Fatalf("Expected rate %.6f for %s, got %.6f", expected, o.Period, o.Value)
+		}
+	}
+}
+
+func TestChainRates_Errors(t *testing.T) {
+	rates := Series{
+		{Period{2024, 1}, 0.5},
+		{Period{2024, 2}, 0.3},
+		{Period{2024, 4}, 0.2},
+	}
+
+	_, err := IndexFromRates(rates, MonthOverMonthRate, Period{2023, 12}, 100)
+	if err == nil {
+		t.Errorf("Expected error for rates with a gap, but got none")
+	}
+
+	_, err = IndexFromRates(rates[:2], MonthOverMonthRate, Period{2025, 1}, 100)
+	if err == nil {
+		t.Errorf("Expected error for an anchor outside the index, but got none")
+	}
+
+	_, err = ChainRates(rates[:2], YearOverYearRate, Series{{Period{2023, 12}, 100}})
+	if err == nil {
+		t.Errorf("Expected error for year-over-year rates with a single seed value, but got none")
+	}
+
+	index, err := IndexFromRates(rates[:2], MonthOverMonthRate, Period{2023, 12}, 100)
+	if err != nil {
+		t.Fatalf("Did not expect error chaining rates, but got: %v", err)
+	}
+	value, _ := index.Lookup(Period{2024, 2})
+	if !floatsAlmostEqual(value, 100*1.005*1.003) {
+		t.Errorf("Expected %.6f for 2024-02, got %.6f", 100*1.005*1.003, value)
+	}
+}
@@ -6,3 +6,5 @@ require (
 	github.com/earentir/inflation v0.0.0-20250110124835-46625d19c3e3
 	github.com/jawher/mow.cli v1.2.0
 )
+
+replace github.com/earentir/inflation => ../
@@ -2,12 +2,14 @@
 package main
 
 import (
-	"encoding/csv"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/earentir/inflation"
@@ -18,13 +20,13 @@ import (
 func main() {
 	app := cli.App("InflationCalculator", "A tool to calculate inflation-adjusted prices.")
 
-	app.Spec = "[--inflation-list] [--cache]"
+	app.Spec = "[--inflation-list] [--cache] [--cache-dir] [--cache-ttl] [--timeout] [--retries] [--output] [--gaps] [--partial-years] [--fiscal-start]"
 
 	// Define the --inflation-list flag
 	inflationList := app.String(cli.StringOpt{
 		Name:  "inflation-list",
-		Desc:  "Path or URL to the inflation rate list JSON file",
-		Value: "inflationratelist.json",
+		Desc:  "Path or URL to the inflation rate list JSON file (uses the embedded data if empty)",
+		Value: "",
 	})
 
 	// Define the --cache flag
@@ -34,298 +36,1254 @@ func main() {
 		Value: false,
 	})
 
+	// Define the --cache-dir flag
+	cacheDir := app.String(cli.StringOpt{
+		Name:  "cache-dir",
+		Desc:  "Directory for cached inflation lists (defaults to the user cache directory)",
+		Value: "",
+	})
+
+	// Define the --cache-ttl flag
+	cacheTTL := app.String(cli.StringOpt{
+		Name:  "cache-ttl",
+		Desc:  "How long a cached inflation list is used before it is revalidated",
+		Value: inflation.DefaultCacheTTL.String(),
+	})
+
+	// Define the --timeout flag
+	timeout := app.String(cli.StringOpt{
+		Name:  "timeout",
+		Desc:  "Timeout for each attempt to download the inflation list",
+		Value: inflation.DefaultTimeout.String(),
+	})
+
+	// Define the --retries flag
+	retries := app.Int(cli.IntOpt{
+		Name:  "retries",
+		Desc:  "How often a failed download of the inflation list is retried",
+		Value: inflation.DefaultRetries,
+	})
+
+	// Define the --output flag
+	outputFormat := app.String(cli.StringOpt{
+		Name:  "output",
+		Desc:  "Output format: text, or records as json, csv or yaml",
+		Value: textOutput,
+	})
+
+	// Define the --gaps flag
+	gaps := app.String(cli.StringOpt{
+		Name:  "gaps",
+		Desc:  "How to treat missing months: error, carry-forward, linear or log-linear",
+		Value: inflation.GapError.String(),
+	})
+
+	// Define the --partial-years flag
+	partialYears := app.Bool(cli.BoolOpt{
+		Name:  "partial-years",
+		Desc:  "Average the months available for years with fewer than 12 months instead of failing",
+		Value: false,
+	})
+
+	// Define the --fiscal-start flag
+	fiscalStart := app.Int(cli.IntOpt{
+		Name:  "fiscal-start",
+		Desc:  "First month (1-12) of fiscal years in dates such as FY2024, which are named by the year they end in",
+		Value: 1,
+	})
+
+	// parseSpan parses a date argument: a year, month, quarter, half or fiscal year.
+	parseSpan := func(dateStr string) (inflation.Span, error) {
+		return inflation.ParseSpan(dateStr, *fiscalStart)
+	}
+
+	// out prints the results of commands in the format selected with --output.
+	var out *output
+	app.Before = func() {
+		var err error
+		out, err = newOutput(*outputFormat, os.Stdout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			cli.Exit(2)
+		}
+		if _, err := inflation.ParseGapPolicy(*gaps); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			cli.Exit(2)
+		}
+		if *fiscalStart < 1 || *fiscalStart > 12 {
+			fmt.Fprintf(os.Stderr, "invalid --fiscal-start %d (use a month from 1 to 12)\n", *fiscalStart)
+			cli.Exit(2)
+		}
+	}
+
+	// loadData loads the inflation list selected by the global options.
+	loadData := func() (*inflation.Loader, error) {
+		timeoutDuration, err := time.ParseDuration(*timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %v", err)
+		}
+		opts := []inflation.LoadOption{
+			inflation.WithTimeout(timeoutDuration),
+			inflation.WithRetries(*retries, inflation.DefaultBackoff),
+		}
+
+		if *cacheList {
+			cache, err := inflation.DefaultCache()
+			if err != nil {
+				return nil, err
+			}
+			if *cacheDir != "" {
+				cache.Dir = *cacheDir
+			}
+			cache.TTL, err = time.ParseDuration(*cacheTTL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cache TTL: %v", err)
+			}
+			opts = append(opts, inflation.WithCache(cache))
+		}
+
+		loader := &inflation.Loader{}
+		err = loader.LoadDataContext(context.Background(), *inflationList, opts...)
+		loader.Data.Gaps, _ = inflation.ParseGapPolicy(*gaps)
+		loader.Data.PartialYears = *partialYears
+		return loader, err
+	}
+
 	// Command: yearInflation
-	app.Command("year", "Get inflation rate for a specific year and country", func(cmd *cli.Cmd) {
+	app.Command("year", "Get the price index level for a specific year and country", func(cmd *cli.Cmd) {
+		country := cmd.StringArg("COUNTRY", "", "Country name or code")
+		dateStr := cmd.StringArg("DATE", "", "Date: "+dateFormats+" or YYYY-MM-DD")
+		series := seriesOption(cmd)
+		projection := projectionOption(cmd)
+
+		cmd.Action = func() {
+			if *country == "" || *dateStr == "" {
+				usageError(cmd, "COUNTRY and DATE are required")
+			}
+
+			day, daily, err := inflation.ParseDay(*dateStr)
+			if err != nil {
+				log.Fatalf("Invalid DATE format: %v", err)
+			}
+			var span inflation.Span
+			if !daily {
+				span, err = parseSpan(*dateStr)
+				if err != nil {
+					log.Fatalf("Invalid DATE format: %v", err)
+				}
+			}
+
+			loader, err := loadData()
+			if err != nil {
+				log.Fatalf("Error loading data: %v", err)
+			}
+
+			var level inflation.IndexValue
+			if daily {
+				level, err = loader.Data.DayIndexLevel(*country, day, queryOptions(*series, *projection)...)
+			} else {
+				level, err = loader.Data.SpanIndexLevel(*country, span, queryOptions(*series, *projection)...)
+			}
+			if err != nil {
+				log.Fatalf("Error fetching index level: %s", hint(err))
+			}
+
+			out.records(indexRecord{Country: countryName(&loader.Data, *country), Series: seriesCode(&loader.Data, *country, *series), IndexValue: level})
+			if daily {
+				out.printf("Index level for %s on %s is %.2f%s\n", *country, *dateStr, level.Value, indexNote(level))
+				out.printf("Method: %s\n", level.Method)
+			} else if span.Months() > 1 {
+				out.printf("Average index level for %s in %s is %.2f%s\n", *country, span, level.Value, indexNote(level))
+			} else {
+				out.printf("Index level for %s in %s is %.2f%s\n", *country, span, level.Value, indexNote(level))
+			}
+		}
+	})
+
+	// Command: rate
+	app.Command("rate", "Get the inflation rate (percent change of the index) for a specific date and country", func(cmd *cli.Cmd) {
+		country := cmd.StringArg("COUNTRY", "", "Country name or code")
+		dateStr := cmd.StringArg("DATE", "", "Date: "+dateFormats)
+		series := seriesOption(cmd)
+		projection := projectionOption(cmd)
+
+		cmd.Action = func() {
+			if *country == "" || *dateStr == "" {
+				usageError(cmd, "COUNTRY and DATE are required")
+			}
+
+			span, err := parseSpan(*dateStr)
+			if err != nil {
+				log.Fatalf("Invalid DATE format: %v", err)
+			}
+			year, month := span.From.Year, span.From.Month
+
+			loader, err := loadData()
+			if err != nil {
+				log.Fatalf("Error loading data: %v", err)
+			}
+
+			level, err := loader.Data.SpanIndexLevel(*country, span, queryOptions(*series, *projection)...)
+			if err != nil {
+				log.Fatalf("Error fetching index level: %s", hint(err))
+			}
+			projected := level.Projected > 0
+
+			if span.Months() > 1 && !span.IsYear() {
+				yoy, err := loader.Data.SpanYearOverYear(*country, span, queryOptions(*series, *projection)...)
+				if err != nil {
+					log.Fatalf("Error computing year-over-year rate: %s", hint(err))
+				}
+				previous, err := loader.Data.SpanOverSpan(*country, span, queryOptions(*series, *projection)...)
+				if err != nil {
+					log.Fatalf("Error computing rate over the previous period: %s", hint(err))
+				}
+
+				code, name := seriesCode(&loader.Data, *country, *series), countryName(&loader.Data, *country)
+				out.records([]rateRecord{
+					{Country: name, Series: code, Period: span.From, Span: span.String(), Kind: "year_over_year", Rate: yoy, Projected: projected},
+					{Country: name, Series: code, Period: span.From, Span: span.String(), Kind: "over_previous", Rate: previous, Projected: projected},
+				})
+				out.printf("Average index level for %s in %s: %.2f%s\n", *country, span, level.Value, indexNote(level))
+				out.printf("Year-over-year inflation rate (%s vs %s): %.2f%%\n", span, span.AddMonths(-12), yoy)
+				out.printf("Inflation rate over the previous period (%s vs %s): %.2f%%\n", span, span.AddMonths(-span.Months()), previous)
+			} else if span.IsYear() {
+				average, err := loader.Data.AnnualAverageRate(*country, year, queryOptions(*series, *projection)...)
+				if err != nil {
+					log.Fatalf("Error computing annual average rate: %s", hint(err))
+				}
+				december, err := loader.Data.DecemberOverDecember(*country, year, queryOptions(*series, *projection)...)
+				if err != nil {
+					log.Fatalf("Error computing December-over-December rate: %s", hint(err))
+				}
+
+				period := inflation.Period{Year: year}
+				code, name := seriesCode(&loader.Data, *country, *series), countryName(&loader.Data, *country)
+				out.records([]rateRecord{
+					{Country: name, Series: code, Period: period, Kind: "annual_average", Rate: average, Projected: projected},
+					{Country: name, Series: code, Period: period, Kind: "december_over_december", Rate: december, Projected: projected},
+				})
+				out.printf("Average index level for %s in %d: %.2f%s\n", *country, year, level.Value, indexNote(level))
+				out.printf("Annual average inflation rate (%d vs %d): %.2f%%\n", year, year-1, average)
+				out.printf("December-over-December inflation rate (%d-12 vs %d-12): %.2f%%\n", year, year-1, december)
+			} else {
+				yoy, err := loader.Data.YearOverYear(*country, year, month, queryOptions(*series, *projection)...)
+				if err != nil {
+					log.Fatalf("Error computing year-over-year rate: %s", hint(err))
+				}
+				mom, err := loader.Data.MonthOverMonth(*country, year, month, queryOptions(*series, *projection)...)
+				if err != nil {
+					log.Fatalf("Error computing month-over-month rate: %s", hint(err))
+				}
+
+				period := inflation.Period{Year: year, Month: month}
+				code, name := seriesCode(&loader.Data, *country, *series), countryName(&loader.Data, *country)
+				out.records([]rateRecord{
+					{Country: name, Series: code, Period: period, Kind: "year_over_year", Rate: yoy, Projected: projected},
+					{Country: name, Series: code, Period: period, Kind: "month_over_month", Rate: mom, Projected: projected},
+				})
+				out.printf("Index level for %s in %d-%02d: %.2f%s\n", *country, year, month, level.Value, indexNote(level))
+				out.printf("Year-over-year inflation rate: %.2f%%\n", yoy)
+				out.printf("Month-over-month inflation rate: %.2f%%\n", mom)
+			}
+		}
+	})
+
+	// Command: compareInflation
+	app.Command("compare", "Compare inflation between two dates for a country", func(cmd *cli.Cmd) {
+		country := cmd.StringArg("COUNTRY", "", "Country name or code")
+		fromDateStr := cmd.StringArg("FROM_DATE", "", "From date: "+dateFormats+" or YYYY-MM-DD")
+		toDateStr := cmd.StringArg("TO_DATE", "", "To date: "+dateFormats+" or YYYY-MM-DD")
+		price := cmd.Float64Arg("PRICE", 0.0, "Original price") // Changed to Float64Arg
+		series := seriesOption(cmd)
+		projection := projectionOption(cmd)
+
+		cmd.Action = func() {
+			if *country == "" || *fromDateStr == "" || *toDateStr == "" || *price == 0.0 {
+				usageError(cmd, "COUNTRY, FROM_DATE, TO_DATE, and PRICE are required")
+			}
+
+			// Days are compared with daily levels, see inflation.DayIndexLevel
+			fromDay, fromDaily, err := inflation.ParseDay(*fromDateStr)
+			if err != nil {
+				log.Fatalf("Invalid FROM_DATE format: %v", err)
+			}
+			toDay, toDaily, err := inflation.ParseDay(*toDateStr)
+			if err != nil {
+				log.Fatalf("Invalid TO_DATE format: %v", err)
+			}
+			if fromDaily != toDaily {
+				log.Fatalf("FROM_DATE and TO_DATE must both be days (YYYY-MM-DD) or both be periods such as years, months or quarters")
+			}
+
+			var from, to inflation.Span
+			if !fromDaily {
+				from, err = parseSpan(*fromDateStr)
+				if err != nil {
+					log.Fatalf("Invalid FROM_DATE format: %v", err)
+				}
+
+				to, err = parseSpan(*toDateStr)
+				if err != nil {
+					log.Fatalf("Invalid TO_DATE format: %v", err)
+				}
+			}
+
+			loader, err := loadData()
+			if err != nil {
+				log.Fatalf("Error loading data: %v", err)
+			}
+
+			var result inflation.Comparison
+			if fromDaily {
+				result, err = loader.Data.CompareDates(*country, fromDay, toDay, *price, queryOptions(*series, *projection)...)
+			} else {
+				result, err = loader.Data.CompareSpans(*country, from, to, *price, queryOptions(*series, *projection)...)
+			}
+			if err != nil {
+				log.Fatalf("Error comparing inflation: %s", hint(err))
+			}
+
+			out.records(result)
+			out.printf("Price adjusted for inflation from %s to %s in %s: %.2f\n", formatIndexDate(result.From), formatIndexDate(result.To), *country, result.Price)
+			out.printf("Cumulative rate of inflation: %.2f%%\n", result.Cumulative)
+			out.printf("Annualized rate of inflation: %.2f%% over %.2f years\n", result.Annualized, result.Years)
+			out.printIndexValues(result)
+		}
+	})
+
+	// Command: compareCountries
+	app.Command("compareCountries", "Compare how a price evolved between two dates in several countries side by side", func(cmd *cli.Cmd) {
+		cmd.Spec = "[--series] [--projection] FROM_DATE TO_DATE PRICE COUNTRY..."
+		fromDateStr := cmd.StringArg("FROM_DATE", "", "From date: "+dateFormats)
+		toDateStr := cmd.StringArg("TO_DATE", "", "To date: "+dateFormats)
+		price := cmd.Float64Arg("PRICE", 0.0, "Original price")
+		countries := cmd.StringsArg("COUNTRY", nil, "Country names or codes")
+		series := seriesOption(cmd)
+		projection := projectionOption(cmd)
+
+		cmd.Action = func() {
+			if *fromDateStr == "" || *toDateStr == "" || *price == 0.0 || len(*countries) == 0 {
+				usageError(cmd, "FROM_DATE, TO_DATE, PRICE, and COUNTRY are required")
+			}
+
+			from, err := parseSpan(*fromDateStr)
+			if err != nil {
+				log.Fatalf("Invalid FROM_DATE format: %v", err)
+			}
+
+			to, err := parseSpan(*toDateStr)
+			if err != nil {
+				log.Fatalf("Invalid TO_DATE format: %v", err)
+			}
+
+			loader, err := loadData()
+			if err != nil {
+				log.Fatalf("Error loading data: %v", err)
+			}
+
+			results, err := loader.Data.CompareCountriesSpans(*countries, from, to, *price, queryOptions(*series, *projection)...)
+			if err != nil {
+				log.Fatalf("Error comparing countries: %v", err)
+			}
+
+			records := make([]comparisonRecord, len(results))
+			failed := 0
+			for i, r := range results {
+				records[i].Comparison = r.Comparison
+				if r.Err != nil {
+					records[i].Error = r.Err.Error()
+					failed++
+				}
+			}
+			out.records(records)
+
+			out.printf("Price of %.2f adjusted for inflation from %s to %s:\n", *price, from, to)
+			if !out.structured() {
+				w := tabwriter.NewWriter(out.w, 0, 0, 2, ' ', tabwriter.AlignRight)
+				fmt.Fprintln(w, "Country\tPrice\tCumulative\tAnnualized\t")
+				projected := false
+				for _, r := range results {
+					if r.Err == nil {
+						name := r.Country
+						if r.Projection != "" {
+							name += " *"
+							projected = true
+						}
+						fmt.Fprintf(w, "%s\t%.2f\t%.2f%%\t%.2f%%\t\n", name, r.Price, r.Cumulative, r.Annualized)
+					}
+				}
+				w.Flush()
+				if projected {
+					fmt.Fprintln(out.w, "* based on projected index levels, see --projection")
+				}
+			}
+			for _, r := range results {
+				if r.Err != nil {
+					log.Printf("Error for %s: %v", r.Country, r.Err)
+				}
+			}
+			if failed > 0 {
+				cli.Exit(1)
+			}
+		}
+	})
+
+	// Command: batch
+	app.Command("batch", "Adjust the amounts of a CSV file (country, date, amount and optional target_date columns) for inflation", func(cmd *cli.Cmd) {
+		cmd.Spec = "[--target] [--delimiter] [--decimal] [--thousands] [--series] [--projection] INPUT_CSV [OUTPUT_CSV]"
+		inputFile := cmd.StringArg("INPUT_CSV", "", "Path to the CSV file with the amounts")
+		outputFile := cmd.StringArg("OUTPUT_CSV", "", "Path to the CSV file to write; standard output if empty")
+		target := cmd.String(cli.StringOpt{
+			Name:  "target",
+			Desc:  "Target date for rows without target_date: YYYY-MM-DD or " + dateFormats + "; the country's latest month if empty",
+			Value: "",
+		})
+		delimiter := cmd.String(cli.StringOpt{
+			Name:  "delimiter",
+			Desc:  "Field delimiter, e.g. ';' or 'tab'",
+			Value: ",",
+		})
+		decimal := cmd.String(cli.StringOpt{
+			Name:  "decimal",
+			Desc:  "Decimal separator of the amounts",
+			Value: ".",
+		})
+		thousands := cmd.String(cli.StringOpt{
+			Name:  "thousands",
+			Desc:  "Thousands separator of the amounts; none if empty",
+			Value: "",
+		})
+		series := seriesOption(cmd)
+		projection := projectionOption(cmd)
+
+		cmd.Action = func() {
+			if *inputFile == "" {
+				usageError(cmd, "INPUT_CSV is required")
+			}
+
+			var opts inflation.BatchOptions
+			var err error
+			opts.Delimiter, err = parseSeparator(*delimiter)
+			if err != nil {
+				log.Fatalf("Invalid --delimiter: %v", err)
+			}
+			opts.Decimal, err = parseSeparator(*decimal)
+			if err != nil {
+				log.Fatalf("Invalid --decimal: %v", err)
+			}
+			opts.Thousands, err = parseSeparator(*thousands)
+			if err != nil {
+				log.Fatalf("Invalid --thousands: %v", err)
+			}
+			if *target != "" {
+				_, daily, err := inflation.ParseDay(*target)
+				if err == nil && !daily {
+					_, err = parseSpan(*target)
+				}
+				if err != nil {
+					log.Fatalf("Invalid --target format: %v", err)
+				}
+				opts.Target = *target
+			}
+			opts.FiscalStart = *fiscalStart
+
+			input, err := os.Open(*inputFile)
+			if err != nil {
+				log.Fatalf("Error opening input CSV: %v", err)
+			}
+			defer input.Close()
+
+			// Load the data once for all rows
+			loader, err := loadData()
+			if err != nil {
+				log.Fatalf("Error loading data: %v", err)
+			}
+
+			w := os.Stdout
+			if *outputFile != "" {
+				w, err = os.Create(*outputFile)
+				if err != nil {
+					log.Fatalf("Error creating output CSV: %v", err)
+				}
+			}
+			result, err := loader.Data.CompareBatch(input, w, opts, queryOptions(*series, *projection)...)
+			if *outputFile != "" {
+				if closeErr := w.Close(); err == nil {
+					err = closeErr
+				}
+			}
+			if err != nil {
+				log.Fatalf("Error processing batch: %v", err)
+			}
+
+			if *outputFile != "" {
+				out.records(result)
+				out.printf("Adjusted %d of %d rows from %s into %s\n", result.Rows-result.Failed, result.Rows, *inputFile, *outputFile)
+			}
+			if result.Failed > 0 {
+				log.Printf("%d of %d rows failed, see the error column", result.Failed, result.Rows)
+				cli.Exit(1)
+			}
+		}
+	})
+
+	// Command: deflate
+	app.Command("deflate", "Express the amounts of a CSV file (date and amount columns) in the prices of a reference date", func(cmd *cli.Cmd) {
+		cmd.Spec = "[--delimiter] [--decimal] [--thousands] [--series] [--projection] COUNTRY CSV_FILE REFERENCE_DATE"
+		country := cmd.StringArg("COUNTRY", "", "Country name or code")
+		csvFile := cmd.StringArg("CSV_FILE", "", "Path to the CSV file with the amounts (dates in YYYY, YYYY-MM or YYYY-MM-DD format)")
+		refDateStr := cmd.StringArg("REFERENCE_DATE", "", "Reference date: "+dateFormats)
+		delimiter := cmd.String(cli.StringOpt{
+			Name:  "delimiter",
+			Desc:  "Field delimiter, e.g. ';' or 'tab'",
+			Value: ",",
+		})
+		decimal := cmd.String(cli.StringOpt{
+			Name:  "decimal",
+			Desc:  "Decimal separator of the amounts",
+			Value: ".",
+		})
+		thousands := cmd.String(cli.StringOpt{
+			Name:  "thousands",
+			Desc:  "Thousands separator of the amounts; none if empty",
+			Value: "",
+		})
+		series := seriesOption(cmd)
+		projection := projectionOption(cmd)
+
+		cmd.Action = func() {
+			if *country == "" || *csvFile == "" || *refDateStr == "" {
+				usageError(cmd, "COUNTRY, CSV_FILE and REFERENCE_DATE are required")
+			}
+
+			ref, err := parseSpan(*refDateStr)
+			if err != nil {
+				log.Fatalf("Invalid REFERENCE_DATE format: %v", err)
+			}
+			delim, err := parseSeparator(*delimiter)
+			if err != nil {
+				log.Fatalf("Invalid --delimiter: %v", err)
+			}
+			dec, err := parseSeparator(*decimal)
+			if err != nil {
+				log.Fatalf("Invalid --decimal: %v", err)
+			}
+			thou, err := parseSeparator(*thousands)
+			if err != nil {
+				log.Fatalf("Invalid --thousands: %v", err)
+			}
+
+			f, err := os.Open(*csvFile)
+			if err != nil {
+				log.Fatalf("Error opening CSV file: %v", err)
+			}
+			amounts, err := inflation.ReadAmounts(f, delim, dec, thou)
+			f.Close()
+			if err != nil {
+				log.Fatalf("Error reading CSV file: %v", err)
+			}
+
+			loader, err := loadData()
+			if err != nil {
+				log.Fatalf("Error loading data: %v", err)
+			}
+
+			values, err := loader.Data.DeflateSpan(*country, amounts, ref, queryOptions(*series, *projection)...)
+			if err != nil {
+				log.Fatalf("Error deflating amounts: %s", hint(err))
+			}
+
+			name := countryName(&loader.Data, *country)
+			records := make([]realValueRecord, len(values))
+			for i, v := range values {
+				records[i] = realValueRecord{Country: name, Reference: ref.String(), RealValue: v}
+			}
+			out.records(records)
+			out.printf("Amounts in %s in prices of %s:\n", *country, ref)
+			for _, v := range values {
+				index := formatIndexDate(v.Index)
+				if v.Index.Average && v.Period.Month != 0 {
+					index += ", annual average"
+				}
+				date := formatIndexDate(inflation.IndexValue{Period: v.Period, Day: v.Day})
+				out.printf("%-10s %12.2f -> %12.2f (index %.2f, %s)%s\n", date, v.Nominal, v.Real, v.Index.Value, index, indexNote(v.Index))
+			}
+		}
+	})
+
+	// Command: compareWithBaseYear
+	app.Command("compareWithBaseYear", "Compare inflation of a price relative to the country's Base Year", func(cmd *cli.Cmd) {
 		country := cmd.StringArg("COUNTRY", "", "Country name or code")
-		dateStr := cmd.StringArg("DATE", "", "Date in YYYY or YYYY-MM format")
+		targetDateStr := cmd.StringArg("TARGET_DATE", "", "Target date: "+dateFormats)
+		price := cmd.Float64Arg("PRICE", 0.0, "Original price") // Changed to Float64Arg
+		series := seriesOption(cmd)
+		projection := projectionOption(cmd)
+
+		cmd.Action = func() {
+			if *country == "" || *targetDateStr == "" || *price == 0.0 {
+				usageError(cmd, "COUNTRY, TARGET_DATE, and PRICE are required")
+			}
+
+			target, err := parseSpan(*targetDateStr)
+			if err != nil {
+				log.Fatalf("Invalid TARGET_DATE format: %v", err)
+			}
+
+			loader, err := loadData()
+			if err != nil {
+				log.Fatalf("Error loading data: %v", err)
+			}
+
+			result, err := loader.Data.CompareSpanWithBaseYear(*country, target, *price, queryOptions(*series, *projection)...)
+			if err != nil {
+				log.Fatalf("Error comparing inflation with Base Year: %s", hint(err))
+			}
+
+			out.records(result)
+			out.printf("Price adjusted for inflation relative to Base Year (%d) to %s in %s: %.2f\n",
+				result.From.Period.Year,
+				target,
+				*country,
+				result.Price)
+			out.printIndexValues(result)
+		}
+	})
+
+	// Command: import
+	app.Command("import", "Import index values from a CSV file into a JSON file for a specific country", func(cmd *cli.Cmd) {
+		country := cmd.StringArg("COUNTRY", "", "Country name or code (with --layout wide-countries: a country column or 'all')")
+		csvFile := cmd.StringArg("CSV_FILE", "", "Path to the CSV file (by default with date,value columns)")
+		jsonFile := cmd.StringArg("JSON_FILE", "", "Path to the inflation JSON file to update")
+		baseYear := cmd.Int(cli.IntOpt{
+			Name: "base-year",
+			Desc: "HICP Base Year of the values, 0 if not known (new countries get 2015); with --rates and --anchor, the last year of the anchor period",
+		})
+		layout := cmd.String(cli.StringOpt{
+			Name:  "layout",
+			Desc:  "CSV layout: long (one row per date), wide-months (one row per year) or wide-countries (one column per country)",
+			Value: "long",
+		})
+		delimiter := cmd.String(cli.StringOpt{
+			Name:  "delimiter",
+			Desc:  "Field delimiter, e.g. ';' or 'tab'",
+			Value: ",",
+		})
+		dateColumn := cmd.String(cli.StringOpt{
+			Name:  "date-column",
+			Desc:  "Header of the date column",
+			Value: "date",
+		})
+		valueColumn := cmd.String(cli.StringOpt{
+			Name:  "value-column",
+			Desc:  "Header of the value column",
+			Value: "value",
+		})
+		dateFormat := cmd.String(cli.StringOpt{
+			Name:  "date-format",
+			Desc:  "Date format, e.g. YYYY-MM, MM/YYYY, YYYYMMM (2024M03), DD.MM.YYYY; auto-detected if empty",
+			Value: "",
+		})
+		decimal := cmd.String(cli.StringOpt{
+			Name:  "decimal",
+			Desc:  "Decimal separator; auto-detected if empty",
+			Value: "",
+		})
+		thousands := cmd.String(cli.StringOpt{
+			Name:  "thousands",
+			Desc:  "Thousands separator; auto-detected if empty",
+			Value: "",
+		})
+		skipRows := cmd.Int(cli.IntOpt{
+			Name:  "skip-rows",
+			Desc:  "Rows to skip before the header",
+			Value: 0,
+		})
+		preview := cmd.Bool(cli.BoolOpt{
+			Name:  "preview",
+			Desc:  "Show the parsed values without saving",
+			Value: false,
+		})
+		rates := cmd.String(cli.StringOpt{
+			Name:  "rates",
+			Desc:  "The values are percentage changes instead of index levels: mom (month-over-month) or yoy (year-over-year)",
+			Value: "",
+		})
+		anchor := cmd.String(cli.StringOpt{
+			Name:  "anchor",
+			Desc:  "Period (" + dateFormats + ") whose average the index built from --rates equals --anchor-value, also setting the base year to its last year; if empty, chains from the existing values",
+			Value: "",
+		})
+		anchorValue := cmd.Float64(cli.Float64Opt{
+			Name:  "anchor-value",
+			Desc:  "Index value (average) of the --anchor period",
+			Value: 100,
+		})
+		series := cmd.String(cli.StringOpt{
+			Name:  "series",
+			Desc:  "COICOP code of the category the values belong to (e.g. CP01, NRG); the headline index if empty",
+			Value: "",
+		})
+		rescale, force := baseOptions(cmd)
+
+		cmd.Action = func() {
+			if *country == "" || *csvFile == "" || *jsonFile == "" {
+				usageError(cmd, "COUNTRY, CSV_FILE, and JSON_FILE are required")
+			}
+
+			opts := inflation.CSVOptions{
+				DateColumn:  *dateColumn,
+				ValueColumn: *valueColumn,
+				DateFormat:  *dateFormat,
+				SkipRows:    *skipRows,
+				Area:        *country,
+			}
+			var err error
+			opts.Layout, err = inflation.ParseCSVLayout(*layout)
+			if err != nil {
+				log.Fatalf("Invalid --layout: %v", err)
+			}
+			opts.Delimiter, err = parseSeparator(*delimiter)
+			if err != nil {
+				log.Fatalf("Invalid --delimiter: %v", err)
+			}
+			opts.Decimal, err = parseSeparator(*decimal)
+			if err != nil {
+				log.Fatalf("Invalid --decimal: %v", err)
+			}
+			opts.Thousands, err = parseSeparator(*thousands)
+			if err != nil {
+				log.Fatalf("Invalid --thousands: %v", err)
+			}
+
+			// Read CSV
+			result, err := inflation.ReadCSVFile(*csvFile, opts)
+			if err != nil {
+				log.Fatalf("Error reading CSV file: %v", err)
+			}
+			for _, skip := range result.Skipped {
+				log.Printf("Skipping line %d: %s", skip.Line, skip.Reason)
+			}
+
+			// Load existing JSON data
+			loader := &inflation.Loader{}
+			err = loader.LoadData(*jsonFile, false) // Not caching when loading
+			if err != nil {
+				log.Fatalf("Error loading JSON data: %v", err)
+			}
+
+			var anchorSpan inflation.Span
+			if *anchor != "" {
+				anchorSpan, err = parseSpan(*anchor)
+				if err != nil {
+					log.Fatalf("Invalid --anchor: %v", err)
+				}
+			}
+
+			var selected []inflation.ImportedSeries
+			for _, in := range result.Series {
+				if opts.Layout == inflation.CSVWideCountries && !strings.EqualFold(*country, "all") && !strings.EqualFold(in.Area, *country) {
+					continue
+				}
+				in.BaseYear = *baseYear
+				in.SubIndex = *series
+				if *rates != "" {
+					in.Series, err = ratesToIndex(&loader.Data, in, *rates, anchorSpan, *anchorValue)
+					if err != nil {
+						log.Fatalf("Error converting rates for %s to an index: %v", in.Area, err)
+					}
+					if *anchor != "" {
+						in.BaseYear = anchorSpan.To.Year
+					}
+				}
+				if _, err := loader.Data.GetCountry(in.Area); err != nil && in.BaseYear == 0 {
+					in.BaseYear = defaultBaseYear
+				}
+				selected = append(selected, in)
+			}
+			if len(selected) == 0 {
+				log.Fatalf("No values for %s found in %s", *country, *csvFile)
+			}
+
+			if *preview {
+				var records []observationRecord
+				for _, in := range selected {
+					for _, o := range in.Series {
+						records = append(records, observationRecord{Area: in.Area, Period: o.Period, Value: o.Value})
+					}
+				}
+				out.records(records)
+				for _, in := range selected {
+					first, _ := in.Series.First()
+					last, _ := in.Series.Last()
+					out.printf("%s: %d values from %s to %s\n", in.Area, in.Series.Len(), first.Period, last.Period)
+					for i, o := range in.Series {
+						if i == 5 && in.Series.Len() > 10 {
+							out.printf("  ... %d more\n", in.Series.Len()-10)
+						}
+						if i < 5 || i >= in.Series.Len()-5 {
+							out.printf("  %s: %.6g\n", o.Period, o.Value)
+						}
+					}
+				}
+				out.printf("Preview: %d records would be imported. %d records skipped due to errors.\n", result.Observations(), len(result.Skipped))
+				return
+			}
+
+			imported := 0
+			var records []importRecord
+			for _, in := range selected {
+				report, err := loader.Data.MergeSeries(in, mergeOptions(*rescale, *force)...)
+				if err != nil {
+					log.Fatalf("Error importing values for %s: %v%s", in.Area, err, baseMismatchHint(err))
+				}
+				records = append(records, importRecord{Key: in.Key, MergeReport: report})
+				if report.Created {
+					out.printf("Country '%s' not found. Created a new country entry.\n", in.Area)
+				}
+				if report.Rescaled != 0 {
+					out.printf("Rescaled values for %s by %.4f to the existing index base: %s\n", in.Area, report.Rescaled, report.BaseMismatch)
+				} else if report.BaseMismatch != nil {
+					out.printf("Warning: values for %s may be on a different index base: %s\n", in.Area, report.BaseMismatch)
+				}
+				imported += in.Series.Len()
+			}
+
+			// Save back to JSON
+			err = inflation.SaveInflationData(loader.Data, *jsonFile)
+			if err != nil {
+				log.Fatalf("Error saving JSON data: %v", err)
+			}
+
+			out.records(records)
+			out.printf("Successfully imported %d records. Skipped %d records due to errors.\n", imported, len(result.Skipped))
+			for _, in := range selected {
+				c, err := loader.Data.GetCountry(in.Area)
+				if err != nil {
+					log.Fatalf("Error retrieving country data: %v", err)
+				}
+				out.printf("Successfully imported inflation rates from %s into %s for country %s with Base Year %d\n", *csvFile, *jsonFile, c.Code, c.BaseYear)
+			}
+		}
+	})
+
+	// Command: importECB
+	app.Command("importECB", "Import HICP index series from an ECB Data Portal export (SDMX-CSV or SDMX-JSON) into a JSON file", func(cmd *cli.Cmd) {
+		exportFile := cmd.StringArg("EXPORT_FILE", "", "Path to the ECB SDMX-CSV or SDMX-JSON export")
+		jsonFile := cmd.StringArg("JSON_FILE", "", "Path to the inflation JSON file to update")
+		item := cmd.String(cli.StringOpt{
+			Name:  "item",
+			Desc:  "ICP_ITEM (COICOP) code of the series to import",
+			Value: inflation.ECBHeadline["ICP_ITEM"],
+		})
+		rescale, force := baseOptions(cmd)
 
 		cmd.Action = func() {
-			if *country == "" || *dateStr == "" {
-				fmt.Println("COUNTRY and DATE are required")
-				cmd.PrintHelp()
-				return
+			if *exportFile == "" || *jsonFile == "" {
+				usageError(cmd, "EXPORT_FILE and JSON_FILE are required")
 			}
 
-			year, month, err := parseDate(*dateStr)
+			imported, err := inflation.ReadECBFile(*exportFile)
 			if err != nil {
-				log.Fatalf("Invalid DATE format: %v", err)
+				log.Fatalf("Error reading ECB export: %v", err)
 			}
 
 			loader := &inflation.Loader{}
-			err = loader.LoadData(*inflationList, *cacheList)
+			err = loader.LoadData(*jsonFile, false)
 			if err != nil {
-				log.Fatalf("Error loading data: %v", err)
+				log.Fatalf("Error loading JSON data: %v", err)
 			}
 
-			rate, err := loader.Data.YearInflation(*country, year, month)
-			if err != nil {
-				log.Fatalf("Error fetching inflation rate: %v", err)
+			filter := map[string]string{}
+			for dim, value := range inflation.ECBHeadline {
+				filter[dim] = value
 			}
+			filter["ICP_ITEM"] = *item
 
-			if month == 0 {
-				fmt.Printf("Average inflation rate for %s in %d is %.2f%%\n", *country, year, rate)
-			} else {
-				fmt.Printf("Inflation rate for %s in %d-%02d is %.2f%%\n", *country, year, month, rate)
+			merged := 0
+			var records []importRecord
+			for _, in := range imported {
+				if !in.MatchDimensions(filter) {
+					continue
+				}
+				report, err := loader.Data.MergeSeries(in, mergeOptions(*rescale, *force)...)
+				if err != nil {
+					log.Fatalf("Error merging series %s: %v%s", in.Key, err, baseMismatchHint(err))
+				}
+				merged++
+				records = append(records, importRecord{Key: in.Key, MergeReport: report})
+				out.printMergeReport(in.Key, report)
+			}
+			if merged == 0 {
+				log.Fatalf("No monthly index series for ICP_ITEM %s found in %s", *item, *exportFile)
+			}
+
+			err = inflation.SaveInflationData(loader.Data, *jsonFile)
+			if err != nil {
+				log.Fatalf("Error saving JSON data: %v", err)
 			}
+			out.records(records)
+			out.printf("Successfully imported %d series from %s into %s\n", merged, *exportFile, *jsonFile)
 		}
 	})
 
-	// Command: compareInflation
-	app.Command("compare", "Compare inflation between two dates for a country", func(cmd *cli.Cmd) {
-		country := cmd.StringArg("COUNTRY", "", "Country name or code")
-		fromDateStr := cmd.StringArg("FROM_DATE", "", "From date in YYYY or YYYY-MM format")
-		toDateStr := cmd.StringArg("TO_DATE", "", "To date in YYYY or YYYY-MM format")
-		price := cmd.Float64Arg("PRICE", 0.0, "Original price") // Changed to Float64Arg
+	// Command: importBLS
+	app.Command("importBLS", "Import a BLS CPI series (flat file cu.data.* or v2 API JSON) into a JSON file", func(cmd *cli.Cmd) {
+		blsFile := cmd.StringArg("BLS_FILE", "", "Path to the BLS flat file or API response")
+		jsonFile := cmd.StringArg("JSON_FILE", "", "Path to the inflation JSON file to update")
+		seriesID := cmd.String(cli.StringOpt{
+			Name:  "series-id",
+			Desc:  "BLS series id to import",
+			Value: inflation.BLSHeadline,
+		})
+		country := cmd.String(cli.StringOpt{
+			Name:  "country",
+			Desc:  "Country or region (e.g. US/Northeast) to import the series into; by default US or the region of the series' area",
+			Value: "",
+		})
+		rescale, force := baseOptions(cmd)
 
 		cmd.Action = func() {
-			if *country == "" || *fromDateStr == "" || *toDateStr == "" || *price == 0.0 {
-				fmt.Println("COUNTRY, FROM_DATE, TO_DATE, and PRICE are required")
-				cmd.PrintHelp()
-				return
+			if *blsFile == "" || *jsonFile == "" {
+				usageError(cmd, "BLS_FILE and JSON_FILE are required")
 			}
 
-			fromYear, fromMonth, err := parseDate(*fromDateStr)
+			imported, err := inflation.ReadBLSFile(*blsFile)
 			if err != nil {
-				log.Fatalf("Invalid FROM_DATE format: %v", err)
+				log.Fatalf("Error reading BLS file: %v", err)
 			}
 
-			toYear, toMonth, err := parseDate(*toDateStr)
+			loader := &inflation.Loader{}
+			err = loader.LoadData(*jsonFile, false)
 			if err != nil {
-				log.Fatalf("Invalid TO_DATE format: %v", err)
+				log.Fatalf("Error loading JSON data: %v", err)
+			}
+
+			var records []importRecord
+			for _, in := range imported {
+				if in.Key != *seriesID {
+					continue
+				}
+				if *country != "" {
+					in.Area = *country
+				}
+				report, err := loader.Data.MergeSeries(in, mergeOptions(*rescale, *force)...)
+				if err != nil {
+					log.Fatalf("Error merging series %s: %v%s", in.Key, err, baseMismatchHint(err))
+				}
+				records = append(records, importRecord{Key: in.Key, MergeReport: report})
+				out.printMergeReport(in.Key, report)
+			}
+			if len(records) == 0 {
+				log.Fatalf("Series %s not found in %s", *seriesID, *blsFile)
+			}
+
+			err = inflation.SaveInflationData(loader.Data, *jsonFile)
+			if err != nil {
+				log.Fatalf("Error saving JSON data: %v", err)
+			}
+			out.records(records)
+			out.printf("Successfully imported %s from %s into %s\n", *seriesID, *blsFile, *jsonFile)
+		}
+	})
+
+	// Command: importEurostat
+	app.Command("importEurostat", "Import HICP indices of all countries from a Eurostat dataset (prc_hicp_midx, JSON-stat or TSV) into a JSON file", func(cmd *cli.Cmd) {
+		datasetFile := cmd.StringArg("DATASET_FILE", "", "Path to the Eurostat JSON-stat or TSV dataset")
+		jsonFile := cmd.StringArg("JSON_FILE", "", "Path to the inflation JSON file to update")
+		unit := cmd.String(cli.StringOpt{
+			Name:  "unit",
+			Desc:  "Index unit to import (I15 is 2015=100)",
+			Value: "I15",
+		})
+		coicop := cmd.String(cli.StringOpt{
+			Name:  "coicop",
+			Desc:  "COICOP code of the series to import",
+			Value: inflation.EurostatHeadline["COICOP"],
+		})
+		dryRun := cmd.Bool(cli.BoolOpt{
+			Name:  "dry-run",
+			Desc:  "Show what would change without saving",
+			Value: false,
+		})
+		rescale, force := baseOptions(cmd)
+
+		cmd.Action = func() {
+			if *datasetFile == "" || *jsonFile == "" {
+				usageError(cmd, "DATASET_FILE and JSON_FILE are required")
+			}
+
+			imported, err := inflation.ReadEurostatFile(*datasetFile)
+			if err != nil {
+				log.Fatalf("Error reading Eurostat dataset: %v", err)
 			}
 
 			loader := &inflation.Loader{}
-			err = loader.LoadData(*inflationList, *cacheList)
+			err = loader.LoadData(*jsonFile, false)
 			if err != nil {
-				log.Fatalf("Error loading data: %v", err)
+				log.Fatalf("Error loading JSON data: %v", err)
+			}
+
+			filter := map[string]string{"UNIT": *unit}
+			for dim, value := range inflation.EurostatHeadline {
+				filter[dim] = value
+			}
+			filter["COICOP"] = *coicop
+
+			var selected []inflation.ImportedSeries
+			for _, in := range imported {
+				if in.MatchDimensions(filter) {
+					selected = append(selected, in)
+				}
+			}
+			if len(selected) == 0 {
+				log.Fatalf("No monthly %s series with unit %s found in %s", *coicop, *unit, *datasetFile)
 			}
 
-			newPrice, cumulativeRate, err := loader.Data.CompareInflation(*country, fromYear, fromMonth, toYear, toMonth, *price)
+			reports, err := loader.Data.MergeAll(selected, *dryRun, mergeOptions(*rescale, *force)...)
 			if err != nil {
-				log.Fatalf("Error comparing inflation: %v", err)
+				log.Fatalf("Error merging Eurostat dataset: %v%s", err, baseMismatchHint(err))
+			}
+			records := make([]importRecord, len(reports))
+			for i, report := range reports {
+				records[i] = importRecord{Key: selected[i].Key, MergeReport: report}
+				out.printMergeReport(selected[i].Key, report)
 			}
 
-			if fromMonth == 0 && toMonth == 0 {
-				fmt.Printf("Price adjusted for inflation from %d to %d in %s: %.2f\nCumulative rate of inflation: %.2f%%\n", fromYear, toYear, *country, newPrice, cumulativeRate)
-			} else if fromMonth != 0 && toMonth != 0 {
-				fmt.Printf("Price adjusted for inflation from %d-%02d to %d-%02d in %s: %.2f\nCumulative rate of inflation: %.2f%%\n", fromYear, fromMonth, toYear, toMonth, *country, newPrice, cumulativeRate)
-			} else if fromMonth != 0 && toMonth == 0 {
-				fmt.Printf("Price adjusted for inflation from %d-%02d to %d in %s: %.2f\nCumulative rate of inflation: %.2f%%\n", fromYear, fromMonth, toYear, *country, newPrice, cumulativeRate)
-			} else if fromMonth == 0 && toMonth != 0 {
-				fmt.Printf("Price adjusted for inflation from %d to %d-%02d in %s: %.2f\nCumulative rate of inflation: %.2f%%\n", fromYear, toYear, toMonth, *country, newPrice, cumulativeRate)
+			if *dryRun {
+				out.records(records)
+				out.printf("Dry run: %d countries would be updated in %s\n", len(reports), *jsonFile)
+				return
+			}
+
+			err = inflation.SaveInflationData(loader.Data, *jsonFile)
+			if err != nil {
+				log.Fatalf("Error saving JSON data: %v", err)
 			}
+			out.records(records)
+			out.printf("Successfully imported %d countries from %s into %s\n", len(reports), *datasetFile, *jsonFile)
 		}
 	})
 
-	// Command: compareWithBaseYear
-	app.Command("compareWithBaseYear", "Compare inflation of a price relative to the country's Base Year", func(cmd *cli.Cmd) {
+	// Command: rebase
+	app.Command("rebase", "Rescale a country's index so that a period (average of its months) equals 100", func(cmd *cli.Cmd) {
 		country := cmd.StringArg("COUNTRY", "", "Country name or code")
-		targetDateStr := cmd.StringArg("TARGET_DATE", "", "Target date in YYYY or YYYY-MM format")
-		price := cmd.Float64Arg("PRICE", 0.0, "Original price") // Changed to Float64Arg
+		baseDateStr := cmd.StringArg("BASE_DATE", "", "New base period: "+dateFormats)
+		jsonFile := cmd.StringArg("JSON_FILE", "", "Path to the inflation JSON file to update")
 
 		cmd.Action = func() {
-			if *country == "" || *targetDateStr == "" || *price == 0.0 {
-				fmt.Println("COUNTRY, TARGET_DATE, and PRICE are required")
-				cmd.PrintHelp()
-				return
+			if *country == "" || *baseDateStr == "" || *jsonFile == "" {
+				usageError(cmd, "COUNTRY, BASE_DATE, and JSON_FILE are required")
 			}
 
-			targetYear, targetMonth, err := parseDate(*targetDateStr)
+			base, err := parseSpan(*baseDateStr)
 			if err != nil {
-				log.Fatalf("Invalid TARGET_DATE format: %v", err)
+				log.Fatalf("Invalid BASE_DATE format: %v", err)
 			}
 
 			loader := &inflation.Loader{}
-			err = loader.LoadData(*inflationList, *cacheList)
+			err = loader.LoadData(*jsonFile, false)
 			if err != nil {
-				log.Fatalf("Error loading data: %v", err)
+				log.Fatalf("Error loading JSON data: %v", err)
 			}
 
-			newPrice, err := loader.Data.CompareInflationWithBaseYear(*country, targetYear, targetMonth, *price)
+			err = loader.Data.RebaseSpan(*country, base)
 			if err != nil {
-				log.Fatalf("Error comparing inflation with Base Year: %v", err)
+				log.Fatalf("Error rebasing: %v", err)
 			}
 
-			countryData, err := loader.Data.GetCountry(*country)
+			err = inflation.SaveInflationData(loader.Data, *jsonFile)
 			if err != nil {
-				log.Fatalf("Error retrieving country data: %v", err)
-			}
-
-			if targetMonth == 0 {
-				fmt.Printf("Price adjusted for inflation relative to Base Year (%d) to %d in %s: %.2f\n",
-					countryData.BaseYear,
-					targetYear,
-					*country,
-					newPrice)
-			} else {
-				fmt.Printf("Price adjusted for inflation relative to Base Year (%d) to %d-%02d in %s: %.2f\n",
-					countryData.BaseYear,
-					targetYear,
-					targetMonth,
-					*country,
-					newPrice)
+				log.Fatalf("Error saving JSON data: %v", err)
 			}
+			out.records(rebaseRecord{Country: countryName(&loader.Data, *country), Base: base.String(), BaseYear: base.To.Year})
+			out.printf("Rebased %s in %s to %s = 100 (Base Year %d)\n", *country, *jsonFile, base, base.To.Year)
 		}
 	})
 
-	// Command: import
-	app.Command("import", "Import inflation rates from a CSV file into a JSON file for a specific country", func(cmd *cli.Cmd) {
+	// Command: splice
+	app.Command("splice", "Extend a country's history with an index from another source, linked by ratio splicing over the overlapping months", func(cmd *cli.Cmd) {
 		country := cmd.StringArg("COUNTRY", "", "Country name or code")
-		csvFile := cmd.StringArg("CSV_FILE", "", "Path to the CSV file with date,value")
+		csvFile := cmd.StringArg("CSV_FILE", "", "Path to the CSV file with date,value columns")
 		jsonFile := cmd.StringArg("JSON_FILE", "", "Path to the inflation JSON file to update")
-		baseYear := cmd.Int(cli.IntOpt{
-			Name:  "base-year",
-			Desc:  "HICP Base Year for the country",
-			Value: 2015, // Default Base Year
+		overlapFrom := cmd.String(cli.StringOpt{
+			Name:  "overlap-from",
+			Desc:  "First month used to link the series (YYYY-MM, or the first month of a year or quarter); all common periods if empty",
+			Value: "",
+		})
+		overlapTo := cmd.String(cli.StringOpt{
+			Name:  "overlap-to",
+			Desc:  "Last month used to link the series (YYYY-MM, or the last month of a year or quarter)",
+			Value: "",
+		})
+		source := cmd.String(cli.StringOpt{
+			Name:  "source",
+			Desc:  "Description of the spliced series, recorded with the splice point",
+			Value: "",
+		})
+		delimiter := cmd.String(cli.StringOpt{
+			Name:  "delimiter",
+			Desc:  "Field delimiter, e.g. ';' or 'tab'",
+			Value: ",",
 		})
 
 		cmd.Action = func() {
 			if *country == "" || *csvFile == "" || *jsonFile == "" {
-				fmt.Println("COUNTRY, CSV_FILE, and JSON_FILE are required")
-				cmd.PrintHelp()
-				return
+				usageError(cmd, "COUNTRY, CSV_FILE, and JSON_FILE are required")
 			}
 
-			// Load existing JSON data
-			loader := &inflation.Loader{}
-			err := loader.LoadData(*jsonFile, false) // Not caching when loading
+			var from, to inflation.Period
+			var err error
+			if *overlapFrom != "" {
+				var span inflation.Span
+				span, err = parseSpan(*overlapFrom)
+				from = span.From
+				if err != nil {
+					log.Fatalf("Invalid --overlap-from: %v", err)
+				}
+			}
+			if *overlapTo != "" {
+				var span inflation.Span
+				span, err = parseSpan(*overlapTo)
+				to = span.To
+				if err != nil {
+					log.Fatalf("Invalid --overlap-to: %v", err)
+				}
+			}
+			if (from == inflation.Period{}) != (to == inflation.Period{}) {
+				log.Fatalf("--overlap-from and --overlap-to must be used together")
+			}
+
+			opts := inflation.CSVOptions{Area: *country}
+			opts.Delimiter, err = parseSeparator(*delimiter)
 			if err != nil {
-				log.Fatalf("Error loading JSON data: %v", err)
+				log.Fatalf("Invalid --delimiter: %v", err)
+			}
+			result, err := inflation.ReadCSVFile(*csvFile, opts)
+			if err != nil {
+				log.Fatalf("Error reading CSV file: %v", err)
+			}
+			for _, skip := range result.Skipped {
+				log.Printf("Skipping line %d: %s", skip.Line, skip.Reason)
+			}
+			if len(result.Series) == 0 {
+				log.Fatalf("No values found in %s", *csvFile)
 			}
 
-			// Find the country; if not found, create a new one
-			c, err := loader.Data.GetCountry(*country)
+			loader := &inflation.Loader{}
+			err = loader.LoadData(*jsonFile, false)
 			if err != nil {
-				// Country not found; create a new one
-				fmt.Printf("Country '%s' not found. Creating a new country entry.\n", *country)
-				newCountry := inflation.Country{
-					Name:      *country, // Assuming country name is same as code; modify as needed
-					Aliases:   []string{},
-					Code:      *country,
-					BaseYear:  *baseYear, // Set BaseYear
-					Inflation: make(map[string]map[string]float64),
-				}
-				loader.Data.Countries = append(loader.Data.Countries, newCountry)
-				// Retrieve the newly added country
-				c, err = loader.Data.GetCountry(*country)
-				if err != nil {
-					log.Fatalf("Error creating new country: %v", err)
-				}
-			} else {
-				if c.BaseYear == 0 {
-					c.BaseYear = *baseYear // Set BaseYear if not already set
-				}
+				log.Fatalf("Error loading JSON data: %v", err)
 			}
 
-			// Read CSV
-			file, err := os.Open(*csvFile)
+			splices, err := loader.Data.Splice(*country, result.Series[0].Series, from, to, *source)
 			if err != nil {
-				log.Fatalf("Error opening CSV file: %v", err)
+				log.Fatalf("Error splicing: %v", err)
 			}
-			defer file.Close()
 
-			reader := csv.NewReader(file)
-			records, err := reader.ReadAll()
+			err = inflation.SaveInflationData(loader.Data, *jsonFile)
 			if err != nil {
-				log.Fatalf("Error reading CSV file: %v", err)
+				log.Fatalf("Error saving JSON data: %v", err)
+			}
+			name := countryName(&loader.Data, *country)
+			records := make([]spliceRecord, len(splices))
+			for i, s := range splices {
+				records[i] = spliceRecord{Country: name, SpliceInfo: s}
 			}
+			out.records(records)
+			for _, s := range splices {
+				out.printf("Spliced %s at %s (overlap %s to %s, ratio %.6f)\n", *country, s.At, s.OverlapFrom, s.OverlapTo, s.Ratio)
+			}
+		}
+	})
+
+	// Command: coverage
+	app.Command("coverage", "Show the first and last observation, missing months and per-year completeness for a country", func(cmd *cli.Cmd) {
+		country := cmd.StringArg("COUNTRY", "", "Country name or code")
+		series := seriesOption(cmd)
 
-			// Expecting headers "date", "value"
-			if len(records) < 1 {
-				log.Fatalf("CSV file is empty")
+		cmd.Action = func() {
+			if *country == "" {
+				usageError(cmd, "COUNTRY is required")
 			}
 
-			headers := records[0]
-			dateIdx := -1
-			valueIdx := -1
-			for i, header := range headers {
-				lowerHeader := strings.ToLower(header)
-				if lowerHeader == "date" {
-					dateIdx = i
-				} else if lowerHeader == "value" {
-					valueIdx = i
-				}
+			loader, err := loadData()
+			if err != nil {
+				log.Fatalf("Error loading data: %v", err)
 			}
 
-			if dateIdx == -1 || valueIdx == -1 {
-				log.Fatalf("CSV file must have 'date' and 'value' columns")
+			c, err := loader.Data.GetCountry(*country)
+			if err != nil {
+				log.Fatalf("Error retrieving country data: %v", err)
 			}
 
-			// Counters for feedback
-			successfulImports := 0
-			skippedImports := 0
+			s, err := c.SeriesFor(*series)
+			if err != nil {
+				log.Fatalf("Error retrieving series: %v", err)
+			}
 
-			// Process each record
-			for _, record := range records[1:] {
-				dateStr := record[dateIdx]
-				valueStr := record[valueIdx]
+			coverage, err := s.Coverage()
+			if err != nil {
+				log.Fatalf("Error computing coverage: %v", err)
+			}
 
-				// Extract only 'YYYY-MM' from 'YYYY-MM-DD'
-				if len(dateStr) >= 7 {
-					dateStr = dateStr[:7]
-				} else {
-					log.Printf("Skipping invalid date format '%s': insufficient length", dateStr)
-					skippedImports++
-					continue
+			records := make([]yearCoverageRecord, len(coverage.Years))
+			for i, yc := range coverage.Years {
+				records[i] = yearCoverageRecord{Country: c.Name, Series: seriesCode(&loader.Data, *country, *series), YearCoverage: yc}
+			}
+			out.records(records)
+			out.printf("Coverage for %s: %s to %s (%d observations)\n", c.Name, coverage.First, coverage.Last, coverage.Observations)
+			if len(coverage.Missing) == 0 {
+				out.println("Missing months: none")
+			} else {
+				missing := make([]string, len(coverage.Missing))
+				for i, p := range coverage.Missing {
+					missing[i] = p.String()
 				}
+				out.printf("Missing months (%d): %s\n", len(missing), strings.Join(missing, ", "))
+			}
 
-				// Parse date
-				date, err := time.Parse("2006-01", dateStr)
-				if err != nil {
-					log.Printf("Skipping invalid date format '%s': %v", dateStr, err)
-					skippedImports++
-					continue
+			out.println("Years:")
+			for _, yc := range coverage.Years {
+				if yc.Complete {
+					out.printf("- %d: complete\n", yc.Year)
+				} else {
+					out.printf("- %d: %d/12 months, missing %v\n", yc.Year, yc.Months, yc.Missing)
 				}
-				yearStr := fmt.Sprintf("%d", date.Year())
-				monthStr := fmt.Sprintf("%02d", date.Month()) // Zero-pad month
+			}
+		}
+	})
 
-				// Handle value: remove quotes and replace ',' with '.'
-				valueStr = strings.Trim(valueStr, "\"")
-				valueStr = strings.ReplaceAll(valueStr, ",", ".")
-				value, err := strconv.ParseFloat(valueStr, 64)
+	// Command: data
+	app.Command("data", "Information about the built-in inflation data", func(cmd *cli.Cmd) {
+		cmd.Command("version", "Show which embedded data snapshot is in use", func(cmd *cli.Cmd) {
+			cmd.Action = func() {
+				version, err := inflation.DefaultVersion()
 				if err != nil {
-					log.Printf("Skipping invalid value '%s': %v", valueStr, err)
-					skippedImports++
-					continue
+					log.Fatalf("Error reading embedded data: %v", err)
 				}
 
-				// Initialize year if not present
-				if c.Inflation == nil {
-					c.Inflation = make(map[string]map[string]float64)
+				out.records(version)
+				if *inflationList != "" {
+					out.printf("Using inflation list %s (the embedded data is not in use)\n", *inflationList)
 				}
-				if _, exists := c.Inflation[yearStr]; !exists {
-					c.Inflation[yearStr] = make(map[string]float64)
-				}
-
-				// Update rate
-				c.Inflation[yearStr][monthStr] = value
-				successfulImports++
-			}
-
-			// Save back to JSON
-			err = inflation.SaveInflationData(loader.Data, *jsonFile)
-			if err != nil {
-				log.Fatalf("Error saving JSON data: %v", err)
+				out.printf("Embedded data snapshot: sha256 %s\n", version.SHA256)
+				out.printf("Size: %d bytes, Countries: %d, Latest observation: %s\n", version.Size, version.Countries, version.Latest)
 			}
-
-			fmt.Printf("Successfully imported %d records. Skipped %d records due to errors.\n", successfulImports, skippedImports)
-			fmt.Printf("Successfully imported inflation rates from %s into %s for country %s with Base Year %d\n", *csvFile, *jsonFile, *country, c.BaseYear)
-		}
+		})
 	})
 
 	// Command: listCountries
 	app.Command("listCountries", "List all available countries", func(cmd *cli.Cmd) {
 		cmd.Action = func() {
-			loader := &inflation.Loader{}
-			err := loader.LoadData(*inflationList, *cacheList)
+			loader, err := loadData()
 			if err != nil {
 				log.Fatalf("Error loading data: %v", err)
 			}
 
-			fmt.Println("Available Countries:")
-			for _, country := range loader.Data.Countries {
-				fmt.Printf("- %s (Code: %s, Aliases: %v, Base Year: %d)\n", country.Name, country.Code, country.Aliases, country.BaseYear)
-			}
+			out.records(countryRecords(loader.Data.Countries, ""))
+			out.println("Available Countries:")
+			out.printCountries(loader.Data.Countries, "", "")
 		}
 	})
 
@@ -340,32 +1298,295 @@ func main() {
 	}
 }
 
-// parseDate parses a date string in "YYYY" or "YYYY-MM" format.
-// Returns year, month (0 if not specified), error
-func parseDate(dateStr string) (int, int, error) {
-	if len(dateStr) == 4 {
-		// "YYYY" format
-		year, err := strconv.Atoi(dateStr)
-		if err != nil {
-			return 0, 0, err
+// countryRecords returns the records of countries and, after each, its regions.
+func countryRecords(countries []inflation.Country, parent string) []countryRecord {
+	records := []countryRecord{}
+	for _, country := range countries {
+		path := country.Code
+		if parent != "" {
+			path = parent + "/" + country.Code
 		}
-		return year, 0, nil
-	} else if len(dateStr) == 7 {
-		// "YYYY-MM" format
-		parts := strings.Split(dateStr, "-")
-		if len(parts) != 2 {
-			return 0, 0, fmt.Errorf("invalid date format: %s", dateStr)
+		record := countryRecord{
+			Path:     path,
+			Name:     country.Name,
+			Code:     country.Code,
+			Aliases:  country.Aliases,
+			BaseYear: country.BaseYear,
+			Series:   country.SeriesCodes(),
 		}
-		year, err := strconv.Atoi(parts[0])
-		if err != nil {
-			return 0, 0, fmt.Errorf("invalid year in date: %v", err)
+		if s, err := country.Series(); err == nil {
+			first, _ := s.First()
+			last, _ := s.Last()
+			record.First, record.Last = first.Period, last.Period
+		}
+		records = append(records, record)
+		records = append(records, countryRecords(country.Regions, path)...)
+	}
+	return records
+}
+
+// printCountries prints countries with their sub-indices and, indented below
+// them, their regions with the path used to select them (e.g. US/Northeast).
+func (o *output) printCountries(countries []inflation.Country, parent, indent string) {
+	for _, country := range countries {
+		if parent == "" {
+			o.printf("%s- %s (Code: %s, Aliases: %v, Base Year: %d)\n", indent, country.Name, country.Code, country.Aliases, country.BaseYear)
+		} else {
+			o.printf("%s- %s (Region: %s/%s, Base Year: %d)\n", indent, country.Name, parent, country.Code, country.BaseYear)
+		}
+		for _, code := range country.SeriesCodes()[1:] {
+			o.printf("%s  * %s: %s\n", indent, code, country.SubIndices[code].Name)
+		}
+		path := country.Code
+		if parent != "" {
+			path = parent + "/" + country.Code
+		}
+		o.printCountries(country.Regions, path, indent+"  ")
+	}
+}
+
+// seriesCode returns the COICOP code a --series value selects for a country, for records.
+func seriesCode(data *inflation.Data, country, selector string) string {
+	c, err := data.GetCountry(country)
+	if err != nil {
+		return selector
+	}
+	code, err := c.SeriesCode(selector)
+	if err != nil {
+		return selector
+	}
+	return code
+}
+
+// countryName returns the name of the country (or region) a query selects,
+// as comparisons report it, or the query if no country matches.
+func countryName(data *inflation.Data, country string) string {
+	c, err := data.GetCountry(country)
+	if err != nil {
+		return country
+	}
+	return c.Name
+}
+
+// usageError prints a message about missing arguments with the command's help
+// to stderr and exits with status 2.
+func usageError(cmd *cli.Cmd, msg string) {
+	fmt.Fprintln(os.Stderr, msg)
+	cmd.PrintHelp()
+	cli.Exit(2)
+}
+
+// projectionOption defines the --projection flag of commands that query an index.
+func projectionOption(cmd *cli.Cmd) *string {
+	return cmd.String(cli.StringOpt{
+		Name:  "projection",
+		Desc:  "Extend the index beyond its last month: constant[:MONTHS] (rate of the last 12 months), trend[:MONTHS] (log-linear) or target:RATE (e.g. target:2 for 2% a year); none if empty",
+		Value: "",
+	})
+}
+
+// queryOptions returns the query options for the --series and --projection flags.
+func queryOptions(series, projection string) []inflation.QueryOption {
+	p, err := inflation.ParseProjection(projection)
+	if err != nil {
+		log.Fatalf("Invalid --projection: %v", err)
+	}
+	opts := []inflation.QueryOption{inflation.WithSeries(series)}
+	if p != nil {
+		opts = append(opts, inflation.WithProjection(p))
+	}
+	return opts
+}
+
+// seriesOption defines the --series flag of commands that query an index.
+func seriesOption(cmd *cli.Cmd) *string {
+	return cmd.String(cli.StringOpt{
+		Name:  "series",
+		Desc:  "Series to use: COICOP code (e.g. CP01) or food, energy, services, housing, core; the headline index if empty",
+		Value: "",
+	})
+}
+
+// baseOptions defines the --rescale and --force flags of import commands.
+func baseOptions(cmd *cli.Cmd) (rescale, force *bool) {
+	rescale = cmd.Bool(cli.BoolOpt{
+		Name:  "rescale",
+		Desc:  "Rescale values on a different index base to the existing values by the ratio over their overlapping months",
+		Value: false,
+	})
+	force = cmd.Bool(cli.BoolOpt{
+		Name:  "force",
+		Desc:  "Import values on a different index base unchanged",
+		Value: false,
+	})
+	return rescale, force
+}
+
+// mergeOptions returns the merge options for the --rescale and --force flags.
+func mergeOptions(rescale, force bool) []inflation.MergeOption {
+	var opts []inflation.MergeOption
+	if rescale {
+		opts = append(opts, inflation.WithRescale())
+	}
+	if force {
+		opts = append(opts, inflation.WithForce())
+	}
+	return opts
+}
+
+// defaultBaseYear is the base year of countries created by import without --base-year.
+const defaultBaseYear = 2015
+
+// baseMismatchHint returns the options that avoid err if it is a refused
+// merge, to be appended to it.
+func baseMismatchHint(err error) string {
+	var mismatch *inflation.BaseMismatchError
+	if !errors.As(err, &mismatch) {
+		return ""
+	}
+	if mismatch.Check.Overlap == 0 {
+		return " (nothing was saved; the values do not overlap the existing ones, so use --force to import them unchanged or rebase the existing values to their base year first)"
+	}
+	return " (nothing was saved; use --rescale to align the values with the existing ones or --force to import them unchanged)"
+}
+
+// hint returns the message of err, followed by the global option that avoids
+// it if there is one.
+func hint(err error) string {
+	var incomplete *inflation.IncompleteError
+	if errors.As(err, &incomplete) {
+		return err.Error() + "; use --partial-years to average the available months"
+	}
+	return err.Error()
+}
+
+// printMergeReport prints the changes made by merging an imported series.
+func (o *output) printMergeReport(key string, report inflation.MergeReport) {
+	action := "Updated"
+	if report.Created {
+		action = "Created"
+	}
+	series := ""
+	if report.Series != inflation.Headline {
+		series = ", Series: " + report.Series
+	}
+	o.printf("%s %s (Code: %s%s) from %s: %d added, %d changed, %d unchanged\n",
+		action, report.Country, report.Code, series, key, len(report.Added), len(report.Changed), report.Unchanged)
+	if report.Rescaled != 0 {
+		o.printf("  Rescaled by %.4f to the existing index base: %s\n", report.Rescaled, report.BaseMismatch)
+	} else if report.BaseMismatch != nil {
+		o.printf("  Warning: values may be on a different index base: %s (use the rebase command to align bases)\n", report.BaseMismatch)
+	}
+	if len(report.Changed) > 0 {
+		changed := make([]string, len(report.Changed))
+		for i, p := range report.Changed {
+			changed[i] = p.String()
+		}
+		o.printf("  Changed periods: %s\n", strings.Join(changed, ", "))
+	}
+}
+
+// ratesToIndex converts an imported series of percentage changes to index levels.
+// Without an anchor (a zero span), the index continues from the existing values of the country's series.
+func ratesToIndex(data *inflation.Data, in inflation.ImportedSeries, kindStr string, anchor inflation.Span, anchorValue float64) (inflation.Series, error) {
+	kind, err := inflation.ParseRateKind(kindStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if anchor != (inflation.Span{}) {
+		return inflation.IndexFromRatesSpan(in.Series, kind, anchor, anchorValue)
+	}
+
+	c, err := data.GetCountry(in.Area)
+	if err != nil {
+		return nil, fmt.Errorf("no existing values to chain from; use --anchor")
+	}
+	existing, err := c.SeriesFor(in.SubIndex)
+	if err != nil {
+		return nil, fmt.Errorf("%v; use --anchor", err)
+	}
+	index, err := inflation.ChainRates(in.Series, kind, existing)
+	if err != nil {
+		return nil, fmt.Errorf("%v; use --anchor", err)
+	}
+	return index, nil
+}
+
+// parseSeparator parses a single separator character; "tab" and "\\t" mean a tab.
+// An empty string returns 0 (auto-detect).
+func parseSeparator(s string) (rune, error) {
+	switch s {
+	case "":
+		return 0, nil
+	case "tab", "\\t":
+		return '\t', nil
+	}
+	runes := []rune(s)
+	if len(runes) != 1 {
+		return 0, fmt.Errorf("separator must be a single character: %q", s)
+	}
+	return runes[0], nil
+}
+
+// printIndexValues prints the index levels a comparison was based on.
+func (o *output) printIndexValues(result inflation.Comparison) {
+	for _, v := range []inflation.IndexValue{result.From, result.To} {
+		if v.Day != 0 {
+			o.printf("Index level %s: %.2f%s\n", formatIndexDate(v), v.Value, indexNote(v))
+			o.printf("  %s\n", v.Method)
+		} else if v.Average {
+			o.printf("Index level %s: %.2f (average of %d months)%s\n", formatIndexDate(v), v.Value, v.Months, indexNote(v))
+		} else {
+			o.printf("Index level %s: %.2f%s\n", formatIndexDate(v), v.Value, indexNote(v))
 		}
-		month, err := strconv.Atoi(parts[1])
-		if err != nil || month < 1 || month > 12 {
-			return 0, 0, fmt.Errorf("invalid month in date: %v", err)
+	}
+	if result.Projection != "" {
+		o.printf("Projected with the %s; this is an estimate, not observed data\n", result.Projection)
+	}
+}
+
+// indexNote marks an index level that is based on projected or filled months.
+func indexNote(v inflation.IndexValue) string {
+	var notes []string
+	switch {
+	case v.Projected == 0:
+	case v.Average:
+		notes = append(notes, fmt.Sprintf("%d of %d months projected", v.Projected, v.Months))
+	default:
+		notes = append(notes, "projected")
+	}
+	if len(v.Interpolated) > 0 {
+		months := make([]string, len(v.Interpolated))
+		for i, p := range v.Interpolated {
+			months[i] = p.String()
 		}
-		return year, month, nil
-	} else {
-		return 0, 0, fmt.Errorf("invalid date format: %s", dateStr)
+		notes = append(notes, "filled: "+strings.Join(months, ", "))
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(notes, "; ") + "]"
+}
+
+// dateFormats lists the formats of date arguments, see inflation.ParseSpan.
+const dateFormats = "YYYY, YYYY-MM, YYYY-Qn, YYYY-Hn or FYYYYY (see --fiscal-start)"
+
+// formatIndexDate formats the date of an index level as YYYY, YYYY-MM, YYYY-MM-DD or its span.
+func formatIndexDate(v inflation.IndexValue) string {
+	if v.Day != 0 {
+		return fmt.Sprintf("%d-%02d-%02d", v.Period.Year, v.Period.Month, v.Day)
+	}
+	if v.Span != "" {
+		return v.Span
+	}
+	return formatDate(v.Period.Year, v.Period.Month)
+}
+
+// formatDate formats a year (month 0) or month as YYYY or YYYY-MM.
+func formatDate(year, month int) string {
+	if month == 0 {
+		return strconv.Itoa(year)
 	}
+	return fmt.Sprintf("%d-%02d", year, month)
 }
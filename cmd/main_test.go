@@ -0,0 +1,153 @@
+// main_test.go
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// argsEnv holds the arguments, separated by newlines, the test binary runs
+// the command with instead of the tests, see runCommand.
+const argsEnv = "INFLATION_CMD_ARGS"
+
+func TestMain(m *testing.M) {
+	if args := os.Getenv(argsEnv); args != "" {
+		os.Args = append([]string{"inflationcmd"}, strings.Split(args, "\n")...)
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runCommand runs the command line tool with args in a separate process, as
+// commands exit on errors, and returns its combined output and whether it succeeded.
+func runCommand(t *testing.T, args ...string) (string, bool) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), argsEnv+"="+strings.Join(args, "\n"))
+	output, err := cmd.CombinedOutput()
+	return string(output), err == nil
+}
+
+// copyFile copies a file into a temporary directory and returns the path of the copy.
+func copyFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	dest := filepath.Join(t.TempDir(), filepath.Base(path))
+	if err := os.WriteFile(dest, content, 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", dest, err)
+	}
+	return dest
+}
+
+func TestImportBLSBaseMismatch(t *testing.T) {
+	jsonFile := copyFile(t, "../data/inflationratelist.json")
+	original, _ := os.ReadFile(jsonFile)
+
+	// The fixture is on the BLS 1982-84 base, the default data on a 2015 base
+	output, ok := runCommand(t, "importBLS", "../testdata/bls_cu.data.txt", jsonFile)
+	if ok || !strings.Contains(output, "different index base") {
+		t.Errorf("Expected the import to fail with a base mismatch, but got: %s", output)
+	}
+	if content, _ := os.ReadFile(jsonFile); !bytes.Equal(content, original) {
+		t.Errorf("Expected the JSON file to be left unchanged")
+	}
+
+	output, ok = runCommand(t, "importBLS", "--rescale", "../testdata/bls_cu.data.txt", jsonFile)
+	if !ok || !strings.Contains(output, "Rescaled by") {
+		t.Fatalf("Expected the import to succeed with --rescale, but got: %s", output)
+	}
+	output, ok = runCommand(t, "--inflation-list", jsonFile, "compare", "US", "2022-12", "2023-01", "100")
+	if !ok || !strings.Contains(output, "Cumulative rate of inflation: 1.") {
+		t.Errorf("Expected a month of inflation of about 1%% after rescaling, but got: %s", output)
+	}
+}
+
+func TestPartialYearsHint(t *testing.T) {
+	// The CH data of the base year 2015 starts in December
+	output, ok := runCommand(t, "compareWithBaseYear", "CH", "2024", "100")
+	if ok || !strings.Contains(output, "--partial-years") {
+		t.Errorf("Expected the error to mention --partial-years, but got: %s", output)
+	}
+	if output, ok := runCommand(t, "--partial-years", "compareWithBaseYear", "CH", "2024", "100"); !ok {
+		t.Errorf("Expected the comparison to succeed with --partial-years, but got: %s", output)
+	}
+}
+
+func TestRecordCountryName(t *testing.T) {
+	tests := [][]string{
+		{"year", "usa", "2024"},
+		{"rate", "usa", "2024-06"},
+		{"compare", "usa", "2015", "2024", "100"},
+		{"coverage", "usa"},
+	}
+	for _, args := range tests {
+		output, ok := runCommand(t, append([]string{"--output", "json"}, args...)...)
+		if !ok || !strings.Contains(output, `"country": "United States"`) || strings.Contains(output, `"usa"`) {
+			t.Errorf("%s: Expected records for the country name United States, but got: %s", args[0], output)
+		}
+	}
+}
+
+func TestImportRatesAnchorSpan(t *testing.T) {
+	jsonFile := copyFile(t, "../data/inflationratelist.json")
+	csvFile := filepath.Join(t.TempDir(), "rates.csv")
+	rates := "date,value\n"
+	for _, year := range []string{"2019", "2020", "2021"} {
+		for _, month := range []string{"01", "02", "03", "04", "05", "06", "07", "08", "09", "10", "11", "12"} {
+			rates += year + "-" + month + ",0.2\n"
+		}
+	}
+	if err := os.WriteFile(csvFile, []byte(rates), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", csvFile, err)
+	}
+
+	output, ok := runCommand(t, "import", "--rates", "mom", "--anchor", "2020", "XX", csvFile, jsonFile)
+	if !ok || !strings.Contains(output, "with Base Year 2020") {
+		t.Fatalf("Expected the import to succeed with Base Year 2020, but got: %s", output)
+	}
+	output, ok = runCommand(t, "--inflation-list", jsonFile, "year", "XX", "2020")
+	if !ok || !strings.Contains(output, "is 100.00") {
+		t.Errorf("Expected the 2020 average to be 100, but got: %s", output)
+	}
+}
+
+func TestCompareCountriesFailure(t *testing.T) {
+	output, ok := runCommand(t, "compareCountries", "2016", "2024", "100", "GR", "XX")
+	if ok || !strings.Contains(output, "Greece") || !strings.Contains(output, "Error for XX") {
+		t.Errorf("Expected the results for Greece and a failure for XX, but got: %s", output)
+	}
+}
+
+func TestImportAfterRebase(t *testing.T) {
+	jsonFile := copyFile(t, "../data/inflationratelist.json")
+	if output, ok := runCommand(t, "rebase", "US", "2020", jsonFile); !ok {
+		t.Fatalf("Expected the rebase to succeed, but got: %s", output)
+	}
+
+	// Months after the existing ones, without a declared base year
+	csvFile := filepath.Join(t.TempDir(), "index.csv")
+	if err := os.WriteFile(csvFile, []byte("date,value\n2030-01,150\n2030-02,151\n"), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", csvFile, err)
+	}
+	output, ok := runCommand(t, "import", "US", csvFile, jsonFile)
+	if !ok || !strings.Contains(output, "with Base Year 2020") {
+		t.Errorf("Expected the import to succeed with Base Year 2020, but got: %s", output)
+	}
+
+	// A declared base year that differs cannot be rescaled without overlap
+	if err := os.WriteFile(csvFile, []byte("date,value\n2031-01,160\n"), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", csvFile, err)
+	}
+	output, ok = runCommand(t, "import", "--base-year", "2015", "--rescale", "US", csvFile, jsonFile)
+	if ok || strings.Contains(output, "use --rescale") || !strings.Contains(output, "--force") {
+		t.Errorf("Expected the import to fail with a hint without --rescale, but got: %s", output)
+	}
+}
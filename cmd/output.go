@@ -0,0 +1,413 @@
+// output.go
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/earentir/inflation"
+)
+
+// Output formats selected with --output.
+const (
+	textOutput = "text"
+	jsonOutput = "json"
+	csvOutput  = "csv"
+	yamlOutput = "yaml"
+)
+
+// output writes the results of a command as sentences (text) or as records
+// with the field names of their JSON tags (json, csv, yaml).
+type output struct {
+	format string
+	w      io.Writer
+}
+
+// newOutput returns an output for a --output value.
+func newOutput(format string, w io.Writer) (*output, error) {
+	switch strings.ToLower(format) {
+	case textOutput, jsonOutput, csvOutput, yamlOutput:
+		return &output{format: strings.ToLower(format), w: w}, nil
+	}
+	return nil, fmt.Errorf("unknown output format '%s' (use text, json, csv or yaml)", format)
+}
+
+// structured reports whether commands should write records instead of sentences.
+func (o *output) structured() bool {
+	return o.format != textOutput
+}
+
+// printf prints a sentence for text output; structured output only has records.
+func (o *output) printf(format string, args ...interface{}) {
+	if !o.structured() {
+		fmt.Fprintf(o.w, format, args...)
+	}
+}
+
+// println prints a line for text output, see printf.
+func (o *output) println(args ...interface{}) {
+	if !o.structured() {
+		fmt.Fprintln(o.w, args...)
+	}
+}
+
+// records writes records for structured output, see write; text output
+// prints sentences with printf instead.
+func (o *output) records(v interface{}) {
+	if !o.structured() {
+		return
+	}
+	err := o.write(v)
+	if err != nil {
+		log.Fatalf("Error writing %s output: %v", o.format, err)
+	}
+}
+
+// write writes a record (a struct) or a list of records (a slice of structs).
+// JSON and YAML keep nested values; CSV flattens them into columns such as
+// from_value, and joins lists with ';'.
+func (o *output) write(v interface{}) error {
+	switch o.format {
+	case jsonOutput:
+		enc := json.NewEncoder(o.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case csvOutput:
+		return o.writeCSV(reflect.ValueOf(v))
+	case yamlOutput:
+		var b strings.Builder
+		writeYAML(&b, reflect.ValueOf(v), "")
+		_, err := io.WriteString(o.w, b.String())
+		return err
+	}
+	return fmt.Errorf("output format '%s' has no records", o.format)
+}
+
+func (o *output) writeCSV(v reflect.Value) error {
+	records := []reflect.Value{v}
+	if v.Kind() == reflect.Slice {
+		records = records[:0]
+		for i := 0; i < v.Len(); i++ {
+			records = append(records, v.Index(i))
+		}
+	}
+
+	w := csv.NewWriter(o.w)
+	for i, record := range records {
+		var names, values []string
+		flattenCSV(record, "", &names, &values)
+		if i == 0 {
+			if err := w.Write(names); err != nil {
+				return err
+			}
+		}
+		if err := w.Write(values); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// recordField is an exported struct field with the name of its JSON tag.
+type recordField struct {
+	name      string
+	omitEmpty bool
+	value     reflect.Value
+}
+
+// recordFields returns the fields of a struct as encoding/json sees them:
+// named by their tag, without "-" fields, and with embedded structs inlined.
+func recordFields(v reflect.Value) []recordField {
+	var fields []recordField
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+		if f.Anonymous && tag[0] == "" && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, recordFields(v.Field(i))...)
+			continue
+		}
+		name := tag[0]
+		if name == "" {
+			name = f.Name
+		}
+		omitEmpty := len(tag) > 1 && tag[1] == "omitempty"
+		fields = append(fields, recordField{name: name, omitEmpty: omitEmpty, value: v.Field(i)})
+	}
+	return fields
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// flattenCSV appends the columns of a value. Nested structs become prefixed
+// columns; a nil pointer to a struct yields empty columns so that every row
+// has the same header.
+func flattenCSV(v reflect.Value, prefix string, names, values *[]string) {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			start := len(*values)
+			flattenCSV(reflect.Zero(v.Type().Elem()), prefix, names, values)
+			for i := start; i < len(*values); i++ {
+				(*values)[i] = ""
+			}
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct && !v.Type().Implements(stringerType) {
+		for _, f := range recordFields(v) {
+			flattenCSV(f.value, prefix+f.name+"_", names, values)
+		}
+		return
+	}
+	*names = append(*names, strings.TrimSuffix(prefix, "_"))
+	*values = append(*values, formatScalar(v))
+}
+
+// formatScalar formats a value for a CSV cell; lists are joined with ';'.
+func formatScalar(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Type().Implements(stringerType) {
+		if v.IsZero() {
+			return ""
+		}
+		return v.Interface().(fmt.Stringer).String()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Slice, reflect.Array:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatScalar(v.Index(i))
+		}
+		return strings.Join(items, ";")
+	case reflect.Map:
+		keys := v.MapKeys()
+		items := make([]string, len(keys))
+		for i, k := range keys {
+			items[i] = formatScalar(k) + "=" + formatScalar(v.MapIndex(k))
+		}
+		sort.Strings(items)
+		return strings.Join(items, ";")
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return formatScalar(v.Elem())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// writeYAML writes a value as block-style YAML with the same structure and
+// field names as its JSON encoding.
+func writeYAML(b *strings.Builder, v reflect.Value, indent string) {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			b.WriteString("null\n")
+			return
+		}
+		writeYAML(b, v.Elem(), indent)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := recordFields(v)
+		first := true
+		for _, f := range fields {
+			if f.omitEmpty && f.value.IsZero() {
+				continue
+			}
+			if !first {
+				b.WriteString(indent)
+			}
+			first = false
+			b.WriteString(f.name + ":")
+			writeYAMLValue(b, f.value, indent)
+		}
+		if first {
+			b.WriteString("{}\n")
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			b.WriteString("[]\n")
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.WriteString(indent)
+			}
+			b.WriteString("- ")
+			writeYAML(b, v.Index(i), indent+"  ")
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		if len(keys) == 0 {
+			b.WriteString("{}\n")
+			return
+		}
+		sort.Slice(keys, func(i, j int) bool { return formatScalar(keys[i]) < formatScalar(keys[j]) })
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteString(indent)
+			}
+			b.WriteString(yamlString(formatScalar(k)) + ":")
+			writeYAMLValue(b, v.MapIndex(k), indent)
+		}
+	case reflect.String:
+		b.WriteString(yamlString(v.String()) + "\n")
+	default:
+		b.WriteString(formatScalar(v) + "\n")
+	}
+}
+
+// writeYAMLValue writes the value of a mapping entry, on the same line for
+// scalars and indented on the following lines for collections.
+func writeYAMLValue(b *strings.Builder, v reflect.Value, indent string) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			b.WriteString(" null\n")
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map:
+		if v.Kind() == reflect.Map && v.Len() == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+		b.WriteString("\n" + indent + "  ")
+		writeYAML(b, v, indent+"  ")
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteString("\n" + indent + "  ")
+		writeYAML(b, v, indent+"  ")
+	default:
+		b.WriteString(" ")
+		writeYAML(b, v, indent)
+	}
+}
+
+// yamlString quotes a string unless YAML reads it back as the same plain string.
+func yamlString(s string) string {
+	if s == "" || strings.ContainsAny(s, ":#{}[],&*!|>'\"%@`\n") || strings.TrimSpace(s) != s || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// Records written by the commands for structured output.
+
+// indexRecord is the index level written by the year command.
+type indexRecord struct {
+	Country string `json:"country"`
+	Series  string `json:"series"` // COICOP code, CP00 for the headline index
+	inflation.IndexValue
+}
+
+// rateRecord is an inflation rate written by the rate command.
+type rateRecord struct {
+	Country string           `json:"country"`
+	Series  string           `json:"series"`
+	Period  inflation.Period `json:"period"`         // Month is 0 for annual rates, the first month for other spans
+	Span    string           `json:"span,omitempty"` // Quarter, half or fiscal year, e.g. 2024-Q3
+	Kind    string           `json:"kind"`           // annual_average, december_over_december, year_over_year, month_over_month or over_previous
+	Rate    float64          `json:"rate"`           // Percent
+	// Rate is based on projected index levels, see --projection
+	Projected bool `json:"projected,omitempty"`
+}
+
+// comparisonRecord is the result for one country of compareCountries.
+type comparisonRecord struct {
+	inflation.Comparison
+	Error string `json:"error,omitempty"`
+}
+
+// countryRecord describes a country or region for listCountries.
+type countryRecord struct {
+	Path     string           `json:"path"` // Selects the country or region, e.g. US/Northeast
+	Name     string           `json:"name"`
+	Code     string           `json:"code"`
+	Aliases  []string         `json:"aliases"`
+	BaseYear int              `json:"base_year"`
+	First    inflation.Period `json:"first"`
+	Last     inflation.Period `json:"last"`
+	Series   []string         `json:"series"` // Headline and sub-index codes
+}
+
+// yearCoverageRecord is the coverage of one year written by the coverage command.
+type yearCoverageRecord struct {
+	Country string `json:"country"`
+	Series  string `json:"series"`
+	inflation.YearCoverage
+}
+
+// importRecord is the result of merging one imported series.
+type importRecord struct {
+	Key string `json:"key"` // Series key used by the source
+	inflation.MergeReport
+}
+
+// observationRecord is a value shown by import --preview.
+type observationRecord struct {
+	Area   string           `json:"area"`
+	Period inflation.Period `json:"period"`
+	Value  float64          `json:"value"`
+}
+
+// spliceRecord is a splice point written by the splice command.
+type spliceRecord struct {
+	Country string `json:"country"`
+	inflation.SpliceInfo
+}
+
+// rebaseRecord is the result of the rebase command.
+type rebaseRecord struct {
+	Country  string `json:"country"`
+	Base     string `json:"base"` // Year, month or span that equals 100
+	BaseYear int    `json:"base_year"`
+}
+
+// realValueRecord is an amount written by the deflate command.
+type realValueRecord struct {
+	Country   string `json:"country"`
+	Reference string `json:"reference"` // Year, month or span the real amounts are priced in
+	inflation.RealValue
+}
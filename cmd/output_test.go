@@ -0,0 +1,100 @@
+// output_test.go
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/earentir/inflation"
+)
+
+// testRecord has the kinds of fields the records of the commands use.
+type testRecord struct {
+	Name   string           `json:"name"`
+	Note   string           `json:"note,omitempty"`
+	Period inflation.Period `json:"period"`
+	Values []float64        `json:"values"`
+	Nested *testNested      `json:"nested"`
+}
+
+// testNested is a nested struct without a String method, flattened into columns for CSV.
+type testNested struct {
+	Year    int   `json:"year"`
+	Missing []int `json:"missing"`
+}
+
+func TestWriteCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   testRecord
+		expected string
+	}{
+		{"plain", testRecord{Name: "Greece", Period: inflation.Period{Year: 2024, Month: 6}, Values: []float64{1.5, 2}},
+			"Greece,,2024-06,1.5;2,,\n"},
+		{"comma", testRecord{Name: "Korea, Republic of"}, "\"Korea, Republic of\",,,,,\n"},
+		{"quotes", testRecord{Name: `say "hi"`}, "\"say \"\"hi\"\"\",,,,,\n"},
+		{"newline", testRecord{Name: "line 1\nline 2"}, "\"line 1\nline 2\",,,,,\n"},
+		{"empty values", testRecord{Nested: &testNested{Year: 2015}}, ",,,,2015,\n"},
+	}
+	header := "name,note,period,values,nested_year,nested_missing\n"
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			o, _ := newOutput(csvOutput, &b)
+			if err := o.write(tt.record); err != nil {
+				t.Fatalf("Did not expect error, but got: %v", err)
+			}
+			if b.String() != header+tt.expected {
+				t.Errorf("Expected %q, but got %q", header+tt.expected, b.String())
+			}
+		})
+	}
+
+	// A list of records has a single header
+	var b bytes.Buffer
+	o, _ := newOutput(csvOutput, &b)
+	if err := o.write([]testRecord{{Name: "a"}, {Name: "b"}}); err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if expected := header + "a,,,,,\nb,,,,,\n"; b.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, b.String())
+	}
+}
+
+func TestWriteYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{"plain", testRecord{Name: "Greece", Note: "HICP", Values: []float64{1.5, 2}},
+			"name: Greece\nnote: HICP\nperiod:\n  year: 0\n  month: 0\nvalues:\n  - 1.5\n  - 2\nnested: null\n"},
+		{"empty values", testRecord{}, "name: \"\"\nperiod:\n  year: 0\n  month: 0\nvalues: []\nnested: null\n"},
+		{"nested", testRecord{Name: "x", Nested: &testNested{Year: 2015, Missing: []int{3, 4}}, Values: []float64{}},
+			"name: x\nperiod:\n  year: 0\n  month: 0\nvalues: []\nnested:\n  year: 2015\n  missing:\n    - 3\n    - 4\n"},
+		{"comma", "Korea, Republic of", "\"Korea, Republic of\"\n"},
+		{"colon", "a: b", "\"a: b\"\n"},
+		{"newline", "line 1\nline 2", "\"line 1\\nline 2\"\n"},
+		{"quotes", `say "hi"`, "\"say \\\"hi\\\"\"\n"},
+		{"boolean", "yes", "\"yes\"\n"},
+		{"number", "2015", "\"2015\"\n"},
+		{"leading space", " x", "\" x\"\n"},
+		{"list", []string{"US", "-1", ""}, "- US\n- \"-1\"\n- \"\"\n"},
+		{"empty list", []string{}, "[]\n"},
+		{"map", map[string]float64{"b": 2, "a": 1}, "a: 1\nb: 2\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			o, _ := newOutput(yamlOutput, &b)
+			if err := o.write(tt.value); err != nil {
+				t.Fatalf("Did not expect error, but got: %v", err)
+			}
+			if b.String() != tt.expected {
+				t.Errorf("Expected %q, but got %q", tt.expected, b.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// inflation/compare.go
+package inflation
+
+import (
+	"fmt"
+	"math"
+)
+
+// Comparison is the result of adjusting a price for inflation between two
+// dates, with the index levels and averaging that were used.
+type Comparison struct {
+	Country       string     `json:"country"`        // Name of the country
+	Code          string     `json:"code"`           // Code of the country
+	Series        string     `json:"series"`         // COICOP code of the index, Headline for the headline index
+	From          IndexValue `json:"from"`           // Index level at the start date
+	To            IndexValue `json:"to"`             // Index level at the end date
+	Factor        float64    `json:"factor"`         // To.Value / From.Value
+	OriginalPrice float64    `json:"original_price"` // Price at the start date
+	Price         float64    `json:"price"`          // Price adjusted for inflation
+	Cumulative    float64    `json:"cumulative"`     // Cumulative inflation in percent
+	Annualized    float64    `json:"annualized"`     // Compound annual inflation rate in percent
+	Years         float64    `json:"years"`          // Time between the dates in years, see Compare
+	// Projection model used if From or To is based on projected months, see WithProjection
+	Projection string `json:"projection,omitempty"`
+}
+
+// Compare adjusts a price for inflation between two dates for a country and
+// returns the adjusted price, the cumulative inflation and the compound annual
+// rate. A date with month 0 uses the average index level of the year. The time
+// between the dates is measured in months, with a year positioned at its
+// middle: 2003-03 to 2024-07 is 21.33 years and 2015 to 2024 is 9 years.
+// The annualized rate is 0 if both dates are the same.
+// It uses the headline index unless another series is selected with WithSeries.
+func (d *Data) Compare(country string, fromYear, fromMonth int, toYear, toMonth int, price float64, opts ...QueryOption) (Comparison, error) {
+	c, s, err := d.countrySeries(country, opts...)
+	if err != nil {
+		return Comparison{}, err
+	}
+	from, err := s.indexValue(country, fromYear, fromMonth)
+	if err != nil {
+		return Comparison{}, err
+	}
+	to, err := s.indexValue(country, toYear, toMonth)
+	if err != nil {
+		return Comparison{}, err
+	}
+	result, err := newComparison(c, newQuery(opts).series, from, to, price)
+	result.Projection = s.projectionName(from, to)
+	return result, err
+}
+
+// CompareWithBaseYear adjusts a price from the average of the country's BaseYear
+// to a target date, see Compare.
+func (d *Data) CompareWithBaseYear(country string, targetYear, targetMonth int, price float64, opts ...QueryOption) (Comparison, error) {
+	return d.CompareSpanWithBaseYear(country, spanOf(targetYear, targetMonth), price, opts...)
+}
+
+// CompareSpanWithBaseYear adjusts a price from the average of the country's
+// BaseYear to the average of a target span, see CompareSpans.
+func (d *Data) CompareSpanWithBaseYear(country string, target Span, price float64, opts ...QueryOption) (Comparison, error) {
+	c, s, err := d.countrySeries(country, opts...)
+	if err != nil {
+		return Comparison{}, err
+	}
+	if c.BaseYear == 0 {
+		return Comparison{}, fmt.Errorf("base year not set for country '%s'", country)
+	}
+	base, err := s.indexValue(country, c.BaseYear, 0)
+	if err != nil {
+		return Comparison{}, fmt.Errorf("error fetching BaseYear inflation rate: %w", err)
+	}
+	targetValue, err := s.spanValue(country, target)
+	if err != nil {
+		return Comparison{}, fmt.Errorf("error fetching target inflation rate: %w", err)
+	}
+	result, err := newComparison(c, newQuery(opts).series, base, targetValue, price)
+	result.Projection = s.projectionName(base, targetValue)
+	return result, err
+}
+
+// newComparison calculates the result of adjusting price between two index levels.
+func newComparison(c *Country, selector string, from, to IndexValue, price float64) (Comparison, error) {
+	code, err := c.SeriesCode(selector)
+	if err != nil {
+		return Comparison{}, err
+	}
+	if from.Value == 0 {
+		return Comparison{}, fmt.Errorf("cannot compare from a zero index level")
+	}
+
+	factor := to.Value / from.Value
+	result := Comparison{
+		Country:       c.Name,
+		Code:          c.Code,
+		Series:        code,
+		From:          from,
+		To:            to,
+		Factor:        factor,
+		OriginalPrice: price,
+		Price:         price * factor,
+		Cumulative:    (factor - 1) * 100,
+		Years:         to.position() - from.position(),
+	}
+	if result.Years != 0 {
+		result.Annualized, err = annualizedRate(factor, result.Years)
+		if err != nil {
+			return Comparison{}, err
+		}
+	}
+	return result, nil
+}
+
+// CountryComparison is the result for one country of CompareCountries.
+type CountryComparison struct {
+	Comparison
+	Err error `json:"-"` // Why the country could not be compared
+}
+
+// CompareCountries adjusts a price between two dates for several countries
+// side by side, see Compare. A country whose data does not cover both dates
+// (with month 0: the complete year) gets an explicit error in its Err field
+// instead of a result; the returned error is only set for invalid arguments.
+func (d *Data) CompareCountries(countries []string, fromYear, fromMonth int, toYear, toMonth int, price float64, opts ...QueryOption) ([]CountryComparison, error) {
+	return d.CompareCountriesSpans(countries, spanOf(fromYear, fromMonth), spanOf(toYear, toMonth), price, opts...)
+}
+
+// CompareCountriesSpans adjusts a price between the averages of two spans for
+// several countries side by side, see CompareCountries and CompareSpans.
+// Without Data.PartialYears, every month of both spans must be covered.
+func (d *Data) CompareCountriesSpans(countries []string, from, to Span, price float64, opts ...QueryOption) ([]CountryComparison, error) {
+	if len(countries) == 0 {
+		return nil, fmt.Errorf("no countries to compare")
+	}
+
+	results := make([]CountryComparison, len(countries))
+	for i, country := range countries {
+		result := &results[i]
+		result.Country = country
+
+		c, s, err := d.countrySeries(country, opts...)
+		if err != nil {
+			result.Err = err
+			continue
+		}
+		result.Country = c.Name
+
+		if !d.PartialYears {
+			coverage, err := s.Coverage()
+			if err == nil {
+				err = coverage.CoversSpan(from)
+			}
+			if err == nil {
+				err = coverage.CoversSpan(to)
+			}
+			if err != nil {
+				result.Err = err
+				continue
+			}
+		}
+
+		result.Comparison, err = d.CompareSpans(country, from, to, price, opts...)
+		if err != nil {
+			result.Err = err
+		}
+	}
+	return results, nil
+}
+
+// yearPosition returns a date as a fractional year. A month is positioned at
+// its start, and a year (month 0) at its middle, since the annual average
+// index describes the middle of the year. A day is positioned at its start,
+// as a fraction of its month.
+func yearPosition(year, month, day int) float64 {
+	if month == 0 {
+		return float64(year) + 5.5/12
+	}
+	position := float64(year) + float64(month-1)/12
+	if day > 0 {
+		position += float64(day-1) / float64(daysIn(year, month)) / 12
+	}
+	return position
+}
+
+// position returns the date of an index level as a fractional year. A span
+// is positioned at the middle of its months.
+func (v IndexValue) position() float64 {
+	if v.Span != "" {
+		return yearPosition(v.Period.Year, v.Period.Month, 0) + float64(v.Period.MonthsUntil(v.through))/24
+	}
+	return yearPosition(v.Period.Year, v.Period.Month, v.Day)
+}
+
+// annualizedRate returns the average annual rate in percent that compounds to
+// factor over the given number of years.
+func annualizedRate(factor, years float64) (float64, error) {
+	if years == 0 {
+		return 0, fmt.Errorf("cannot annualize a rate over zero time")
+	}
+	if factor <= 0 {
+		return 0, fmt.Errorf("cannot annualize a non-positive factor %g", factor)
+	}
+	return (math.Pow(factor, 1/years) - 1) * 100, nil
+}
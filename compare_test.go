@@ -0,0 +1,152 @@
+// compare_test.go
+package inflation
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	data := createTestData()
+
+	tests := []struct {
+		name                string
+		fromYear, fromMonth int
+		toYear, toMonth     int
+		expectedYears       float64
+		expectedAnnualized  float64
+		expectError         bool
+	}{
+		{"Months", 2015, 1, 2016, 1, 1, 50, false},
+		{"Years", 2015, 0, 2018, 0, 3, (math.Pow(1.5, 1.0/3) - 1) * 100, false},
+		{"Same date", 2015, 1, 2015, 1, 0, 0, false},
+		{"Missing year", 2015, 0, 2017, 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := data.Compare("US", tt.fromYear, tt.fromMonth, tt.toYear, tt.toMonth, 100)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %s, but got none", tt.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Did not expect error for %s, but got: %v", tt.name, err)
+			}
+			if !floatsAlmostEqual(result.Years, tt.expectedYears) || !floatsAlmostEqual(result.Annualized, tt.expectedAnnualized) {
+				t.Errorf("Expected %f years at %f%%, but got %f years at %f%%", tt.expectedYears, tt.expectedAnnualized, result.Years, result.Annualized)
+			}
+		})
+	}
+}
+
+func TestCompareResult(t *testing.T) {
+	data := createTestData()
+
+	result, err := data.Compare("USA", 2015, 0, 2016, 3, 50)
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if result.Country != "United States" || result.Code != "US" || result.Series != Headline {
+		t.Errorf("Unexpected country or series in %+v", result)
+	}
+	expectedFrom := IndexValue{Period: Period{2015, 0}, Value: 0.2, Average: true, Months: 12}
+	if result.From.Period != expectedFrom.Period || !floatsAlmostEqual(result.From.Value, expectedFrom.Value) || !result.From.Average || result.From.Months != 12 {
+		t.Errorf("Expected from %+v, but got %+v", expectedFrom, result.From)
+	}
+	if result.To.Period != (Period{2016, 3}) || result.To.Value != 0.35 || result.To.Average || result.To.Months != 1 {
+		t.Errorf("Unexpected to %+v", result.To)
+	}
+	if !floatsAlmostEqual(result.Factor, 1.75) || result.OriginalPrice != 50 || !floatsAlmostEqual(result.Price, 87.5) {
+		t.Errorf("Unexpected factor or prices in %+v", result)
+	}
+	if result.From.Period.String() != "2015" {
+		t.Errorf("Expected the annual period to format as 2015, but got %s", result.From.Period)
+	}
+
+	base, err := data.CompareWithBaseYear("US", 2018, 0, 100)
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if base.From.Period != (Period{2015, 0}) || !floatsAlmostEqual(base.Price, 150) || !floatsAlmostEqual(base.Years, 3) {
+		t.Errorf("Unexpected base year comparison %+v", base)
+	}
+	if _, err := data.CompareWithBaseYear("US", 2017, 0, 100); err == nil {
+		t.Errorf("Expected error for missing target year, but got none")
+	}
+}
+
+func TestCompareCountries(t *testing.T) {
+	data := createTestData()
+
+	results, err := data.CompareCountries([]string{"US", "DE", "ES"}, 2015, 0, 2016, 0, 100)
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, but got %d", len(results))
+	}
+
+	us := results[0]
+	if us.Err != nil {
+		t.Fatalf("Did not expect error for US, but got: %v", us.Err)
+	}
+	// 2015 average 0.2, 2016 average 0.25
+	if us.Country != "United States" || !floatsAlmostEqual(us.Price, 125) || !floatsAlmostEqual(us.Cumulative, 25) || !floatsAlmostEqual(us.Annualized, 25) {
+		t.Errorf("Unexpected result for US: %+v", us)
+	}
+
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "no data for 2016") {
+		t.Errorf("Expected a coverage error for Germany, but got: %v", results[1].Err)
+	}
+	if results[2].Err == nil {
+		t.Errorf("Expected error for unknown country, but got none")
+	}
+
+	if _, err := data.CompareCountries(nil, 2015, 0, 2016, 0, 100); err == nil {
+		t.Errorf("Expected error for no countries, but got none")
+	}
+}
+
+func TestCoverageCovers(t *testing.T) {
+	s := Series{
+		{Period{2015, 12}, 1},
+		{Period{2016, 1}, 1},
+		{Period{2016, 3}, 1},
+	}
+	coverage, _ := s.Coverage()
+
+	tests := []struct {
+		year, month int
+		expectError bool
+	}{
+		{2015, 12, false},
+		{2016, 1, false},
+		{2016, 2, true},
+		{2016, 4, true},
+		{2015, 0, true},
+		{2014, 0, true},
+		{2016, 13, true},
+	}
+	for _, tt := range tests {
+		err := coverage.Covers(tt.year, tt.month)
+		if tt.expectError && err == nil {
+			t.Errorf("Expected error for %d-%02d, but got none", tt.year, tt.month)
+		}
+		if !tt.expectError && err != nil {
+			t.Errorf("Did not expect error for %d-%02d, but got: %v", tt.year, tt.month, err)
+		}
+	}
+}
+
+func TestAnnualizedRate(t *testing.T) {
+	if rate, _ := annualizedRate(1.21, 2); !floatsAlmostEqual(rate, 10) {
+		t.Errorf("Expected 10%%, but got %f", rate)
+	}
+	if _, err := annualizedRate(1.1, 0); err == nil {
+		t.Errorf("Expected error for zero years, but got none")
+	}
+}
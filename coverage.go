@@ -0,0 +1,126 @@
+// inflation/coverage.go
+package inflation
+
+import (
+	"fmt"
+)
+
+// Coverage describes which periods of a country's series have observations.
+type Coverage struct {
+	First        Period         `json:"first"`
+	Last         Period         `json:"last"`
+	Observations int            `json:"observations"`
+	Missing      []Period       `json:"missing"` // Months without a value between First and Last
+	Years        []YearCoverage `json:"years"`
+}
+
+// YearCoverage describes the observations available for a single calendar year.
+type YearCoverage struct {
+	Year     int   `json:"year"`
+	Months   int   `json:"months"`  // Number of months with a value
+	Missing  []int `json:"missing"` // Months (1-12) without a value
+	Complete bool  `json:"complete"`
+}
+
+// Gaps returns the months without a value between the first and last observation.
+func (s Series) Gaps() []Period {
+	gaps := []Period{}
+	for i := 1; i < len(s); i++ {
+		for p := s[i-1].Period.AddMonths(1); p.Before(s[i].Period); p = p.AddMonths(1) {
+			gaps = append(gaps, p)
+		}
+	}
+	return gaps
+}
+
+// Coverage reports the exact first and last observation of the series, the
+// months missing in between, and the completeness of every calendar year in
+// that range. It fails if the series is empty.
+func (s Series) Coverage() (Coverage, error) {
+	first, ok := s.First()
+	if !ok {
+		return Coverage{}, fmt.Errorf("no inflation data available")
+	}
+	last, _ := s.Last()
+
+	coverage := Coverage{
+		First:        first.Period,
+		Last:         last.Period,
+		Observations: s.Len(),
+		Missing:      s.Gaps(),
+	}
+	for year := first.Period.Year; year <= last.Period.Year; year++ {
+		yearData := s.Year(year)
+		yc := YearCoverage{Year: year, Months: yearData.Len(), Missing: []int{}}
+		for month := 1; month <= 12; month++ {
+			if _, exists := yearData.Lookup(Period{Year: year, Month: month}); !exists {
+				yc.Missing = append(yc.Missing, month)
+			}
+		}
+		yc.Complete = len(yc.Missing) == 0
+		coverage.Years = append(coverage.Years, yc)
+	}
+	return coverage, nil
+}
+
+// Coverage reports the observed range of the country's series; see Series.Coverage.
+func (c *Country) Coverage() (Coverage, error) {
+	s, err := c.Series()
+	if err != nil {
+		return Coverage{}, err
+	}
+	coverage, err := s.Coverage()
+	if err != nil {
+		return Coverage{}, fmt.Errorf("country '%s': %v", c.Name, err)
+	}
+	return coverage, nil
+}
+
+// Covers checks that a date lies within the coverage: a month (1-12) must have
+// a value, and with month 0 the calendar year must be complete. The error
+// names the range that is actually covered.
+func (c Coverage) Covers(year, month int) error {
+	if month < 0 || month > 12 {
+		return fmt.Errorf("invalid month: %d", month)
+	}
+	if month == 0 {
+		for _, yc := range c.Years {
+			if yc.Year != year || yc.Months == 0 {
+				continue
+			}
+			if !yc.Complete {
+				return fmt.Errorf("only %d of 12 months available for %d (data covers %s to %s)", yc.Months, year, c.First, c.Last)
+			}
+			return nil
+		}
+		return fmt.Errorf("no data for %d (data covers %s to %s)", year, c.First, c.Last)
+	}
+
+	p := Period{Year: year, Month: month}
+	if p.Before(c.First) || c.Last.Before(p) {
+		return fmt.Errorf("no data for %s (data covers %s to %s)", p, c.First, c.Last)
+	}
+	for _, missing := range c.Missing {
+		if missing == p {
+			return fmt.Errorf("no data for %s (missing month)", p)
+		}
+	}
+	return nil
+}
+
+// CoversSpan checks that every month of a span lies within the coverage, see
+// Covers; a calendar year is checked like month 0.
+func (c Coverage) CoversSpan(span Span) error {
+	if span.IsYear() {
+		return c.Covers(span.From.Year, 0)
+	}
+	for p := span.From; !span.To.Before(p); p = p.AddMonths(1) {
+		if err := c.Covers(p.Year, p.Month); err != nil {
+			if span.Months() > 1 {
+				return fmt.Errorf("%s: %v", span, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// coverage_test.go
+package inflation
+
+import (
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	country := Country{
+		Name: "Partial",
+		Code: "PA",
+		Inflation: map[string]map[string]float64{
+			"2015": {"11": 99, "12": 100},
+			"2016": {"01": 100, "02": 101, "03": 101, "04": 102, "05": 102, "06": 103, "07": 103, "08": 104, "09": 104, "10": 105, "11": 105, "12": 106},
+			"2018": {"01": 110, "03": 111},
+		},
+	}
+
+	coverage, err := country.Coverage()
+	if err != nil {
+		t.Fatalf("Did not expect error for coverage, but got: %v", err)
+	}
+
+	if coverage.First != (Period{Year: 2015, Month: 11}) {
+		t.Errorf("Expected first period 2015-11, got %s", coverage.First)
+	}
+	// The last year is partial; the last month must be the one observed, not the maximum across years.
+	if coverage.Last != (Period{Year: 2018, Month: 3}) {
+		t.Errorf("Expected last period 2018-03, got %s", coverage.Last)
+	}
+	if coverage.Observations != 16 {
+		t.Errorf("Expected 16 observations, got %d", coverage.Observations)
+	}
+
+	// All of 2017 and 2018-02 are missing
+	if len(coverage.Missing) != 13 {
+		t.Fatalf("Expected 13 missing months, got %d: %v", len(coverage.Missing), coverage.Missing)
+	}
+	if coverage.Missing[0] != (Period{Year: 2017, Month: 1}) || coverage.Missing[12] != (Period{Year: 2018, Month: 2}) {
+		t.Errorf("Expected missing months from 2017-01 to 2018-02, got %s to %s", coverage.Missing[0], coverage.Missing[12])
+	}
+
+	expectedYears := []struct {
+		year     int
+		months   int
+		complete bool
+	}{
+		{2015, 2, false},
+		{2016, 12, true},
+		{2017, 0, false},
+		{2018, 2, false},
+	}
+	if len(coverage.Years) != len(expectedYears) {
+		t.Fatalf("Expected %d years, got %d", len(expectedYears), len(coverage.Years))
+	}
+	for i, expected := range expectedYears {
+		yc := coverage.Years[i]
+		if yc.Year != expected.year || yc.Months != expected.months || yc.Complete != expected.complete {
+			t.Errorf("Expected year %d with %d months (complete=%v), got year %d with %d months (complete=%v)",
+				expected.year, expected.months, expected.complete, yc.Year, yc.Months, yc.Complete)
+		}
+		if len(yc.Missing)+yc.Months != 12 {
+			t.Errorf("For year %d, expected missing and present months to add up to 12, got %d + %d", yc.Year, len(yc.Missing), yc.Months)
+		}
+	}
+
+	year, month := country.GetLastDate()
+	if year != 2018 || month != 3 {
+		t.Errorf("Expected last date 2018-03, got %d-%02d", year, month)
+	}
+}
+
+func TestCoverage_NoData(t *testing.T) {
+	country := Country{Name: "Spain", Code: "ES", Inflation: map[string]map[string]float64{}}
+
+	_, err := country.Coverage()
+	if err == nil {
+		t.Errorf("Expected error for coverage of a country without data, but got none")
+	}
+
+	year, month := country.GetFirstDate()
+	if year != 0 || month != 0 {
+		t.Errorf("Expected first date 0-00 for a country without data, got %d-%02d", year, month)
+	}
+}
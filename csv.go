@@ -0,0 +1,415 @@
+// inflation/csv.go
+package inflation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVLayout describes how observations are arranged in a CSV file.
+type CSVLayout int
+
+const (
+	// CSVLong has one row per observation with a date and a value column,
+	// optionally with a country column.
+	CSVLong CSVLayout = iota
+	// CSVWideMonths has one row per year and one column per month.
+	CSVWideMonths
+	// CSVWideCountries has one row per date and one column per country.
+	CSVWideCountries
+)
+
+// ParseCSVLayout parses "long", "wide-months" or "wide-countries".
+func ParseCSVLayout(s string) (CSVLayout, error) {
+	switch strings.ToLower(s) {
+	case "", "long":
+		return CSVLong, nil
+	case "wide-months":
+		return CSVWideMonths, nil
+	case "wide-countries":
+		return CSVWideCountries, nil
+	default:
+		return CSVLong, fmt.Errorf("unknown CSV layout '%s'", s)
+	}
+}
+
+// CSVOptions configures ReadCSV. The zero value reads a comma separated long
+// layout file with "date" and "value" columns and auto-detected date and number formats.
+type CSVOptions struct {
+	Layout        CSVLayout
+	Delimiter     rune   // Field delimiter, ',' if zero
+	DateColumn    string // Header of the date column, "date" if empty (long and wide-countries layouts)
+	ValueColumn   string // Header of the value column, "value" if empty (long layout)
+	CountryColumn string // Header of an optional country column (long layout)
+	YearColumn    string // Header of the year column, "year" if empty (wide-months layout)
+	DateFormat    string // One of DateFormats, a Go time layout, or "" to auto-detect
+	Decimal       rune   // Decimal separator, auto-detected if zero
+	Thousands     rune   // Thousands separator to remove, auto-detected if zero and Decimal is zero
+	SkipRows      int    // Rows to skip before the header
+	Area          string // Country for series without a country column
+}
+
+// DateFormats are the named date formats accepted in CSVOptions.DateFormat.
+var DateFormats = map[string]string{
+	"YYYY-MM":    "2006-01",
+	"YYYY-MM-DD": "2006-01-02",
+	"YYYY/MM":    "2006/01",
+	"YYYYMM":     "200601",
+	"YYYYMMM":    "2006M01", // e.g. 2024M03
+	"MM/YYYY":    "01/2006",
+	"MM.YYYY":    "01.2006",
+	"MM-YYYY":    "01-2006",
+	"DD/MM/YYYY": "02/01/2006",
+	"DD.MM.YYYY": "02.01.2006",
+	"MM/DD/YYYY": "01/02/2006",
+	"MMM YYYY":   "Jan 2006",
+}
+
+// autoDateLayouts are tried in order when no date format is configured.
+// Day-first and month-first dates are ambiguous and need an explicit format.
+var autoDateLayouts = []string{
+	"2006-01-02", "2006-01", "2006/01", "2006M01", "2006-M01", "200601",
+	"01/2006", "01.2006", "01-2006", "Jan 2006", "January 2006", "Jan-2006", "2006-01-02T15:04:05Z07:00",
+}
+
+// CSVSkip describes a row or cell that could not be imported.
+type CSVSkip struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// CSVResult holds the series read from a CSV file.
+type CSVResult struct {
+	Series  []ImportedSeries
+	Skipped []CSVSkip
+}
+
+// Observations returns the number of imported observations.
+func (r CSVResult) Observations() int {
+	n := 0
+	for _, in := range r.Series {
+		n += in.Series.Len()
+	}
+	return n
+}
+
+// ReadCSVFile reads index values from a CSV file, see ReadCSV.
+func ReadCSVFile(path string, opts CSVOptions) (CSVResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return CSVResult{}, err
+	}
+	defer file.Close()
+	return ReadCSV(file, opts)
+}
+
+// ReadCSV reads index values from CSV data laid out as described by opts.
+// Rows with unparseable dates or values are reported in CSVResult.Skipped
+// instead of failing the whole file.
+func ReadCSV(r io.Reader, opts CSVOptions) (CSVResult, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ','
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return CSVResult{}, fmt.Errorf("error reading CSV file: %v", err)
+	}
+	if opts.SkipRows > 0 {
+		if opts.SkipRows >= len(records) {
+			records = nil
+		} else {
+			records = records[opts.SkipRows:]
+		}
+	}
+	if len(records) < 1 {
+		return CSVResult{}, fmt.Errorf("CSV file is empty")
+	}
+	records[0][0] = strings.TrimPrefix(records[0][0], "\ufeff")
+
+	c := &csvReader{opts: opts, firstLine: opts.SkipRows + 1, bySeries: make(map[string]*ImportedSeries)}
+	switch opts.Layout {
+	case CSVLong:
+		err = c.readLong(records)
+	case CSVWideMonths:
+		err = c.readWideMonths(records)
+	case CSVWideCountries:
+		err = c.readWideCountries(records)
+	default:
+		err = fmt.Errorf("unknown CSV layout %d", opts.Layout)
+	}
+	if err != nil {
+		return CSVResult{}, err
+	}
+
+	result := CSVResult{Skipped: c.skipped}
+	for _, area := range c.order {
+		in := c.bySeries[area]
+		in.Series = sortSeries(in.Series)
+		result.Series = append(result.Series, *in)
+	}
+	return result, nil
+}
+
+// csvReader collects observations per country while reading a CSV file.
+type csvReader struct {
+	opts      CSVOptions
+	firstLine int // Line number of the header
+	bySeries  map[string]*ImportedSeries
+	order     []string
+	skipped   []CSVSkip
+}
+
+func (c *csvReader) skip(row int, format string, args ...interface{}) {
+	c.skipped = append(c.skipped, CSVSkip{Line: c.firstLine + row, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (c *csvReader) add(area string, p Period, value float64) {
+	in, exists := c.bySeries[area]
+	if !exists {
+		in = &ImportedSeries{Key: area, Area: area}
+		c.bySeries[area] = in
+		c.order = append(c.order, area)
+	}
+	in.Series = append(in.Series, Observation{Period: p, Value: value})
+}
+
+// column returns the index of the header matching name case-insensitively, or -1.
+func column(headers []string, name string) int {
+	for i, header := range headers {
+		if strings.EqualFold(strings.TrimSpace(header), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func (c *csvReader) readLong(records [][]string) error {
+	headers := records[0]
+	dateIdx := column(headers, orDefault(c.opts.DateColumn, "date"))
+	valueIdx := column(headers, orDefault(c.opts.ValueColumn, "value"))
+	if dateIdx == -1 || valueIdx == -1 {
+		return fmt.Errorf("CSV file must have '%s' and '%s' columns", orDefault(c.opts.DateColumn, "date"), orDefault(c.opts.ValueColumn, "value"))
+	}
+	countryIdx := -1
+	if c.opts.CountryColumn != "" {
+		countryIdx = column(headers, c.opts.CountryColumn)
+		if countryIdx == -1 {
+			return fmt.Errorf("CSV file must have a '%s' column", c.opts.CountryColumn)
+		}
+	}
+
+	for row, record := range records[1:] {
+		row++
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		p, err := ParseDateFormat(field(record, dateIdx), c.opts.DateFormat)
+		if err != nil {
+			c.skip(row, "invalid date '%s': %v", field(record, dateIdx), err)
+			continue
+		}
+		value, err := ParseNumber(field(record, valueIdx), c.opts.Decimal, c.opts.Thousands)
+		if err != nil {
+			c.skip(row, "invalid value '%s': %v", field(record, valueIdx), err)
+			continue
+		}
+		area := c.opts.Area
+		if countryIdx != -1 {
+			area = field(record, countryIdx)
+		}
+		c.add(area, p, value)
+	}
+	return nil
+}
+
+func (c *csvReader) readWideMonths(records [][]string) error {
+	headers := records[0]
+	yearIdx := column(headers, orDefault(c.opts.YearColumn, "year"))
+	if yearIdx == -1 {
+		yearIdx = 0
+	}
+	months := make(map[int]int) // column -> month
+	for i, header := range headers {
+		if i == yearIdx {
+			continue
+		}
+		if month, ok := parseMonthName(header); ok {
+			months[i] = month
+		}
+	}
+	if len(months) == 0 {
+		return fmt.Errorf("CSV file has no month columns (e.g. 'Jan', '01' or 'M01')")
+	}
+
+	for row, record := range records[1:] {
+		row++
+		yearStr := field(record, yearIdx)
+		if yearStr == "" {
+			continue
+		}
+		year, err := strconv.Atoi(yearStr)
+		if err != nil {
+			c.skip(row, "invalid year '%s'", yearStr)
+			continue
+		}
+		for i := range headers {
+			month, ok := months[i]
+			if !ok || field(record, i) == "" {
+				continue
+			}
+			value, err := ParseNumber(field(record, i), c.opts.Decimal, c.opts.Thousands)
+			if err != nil {
+				c.skip(row, "invalid value '%s' for %d-%02d: %v", field(record, i), year, month, err)
+				continue
+			}
+			c.add(c.opts.Area, Period{Year: year, Month: month}, value)
+		}
+	}
+	return nil
+}
+
+func (c *csvReader) readWideCountries(records [][]string) error {
+	headers := records[0]
+	dateIdx := column(headers, orDefault(c.opts.DateColumn, "date"))
+	if dateIdx == -1 {
+		dateIdx = 0
+	}
+
+	for row, record := range records[1:] {
+		row++
+		dateStr := field(record, dateIdx)
+		if dateStr == "" {
+			continue
+		}
+		p, err := ParseDateFormat(dateStr, c.opts.DateFormat)
+		if err != nil {
+			c.skip(row, "invalid date '%s': %v", dateStr, err)
+			continue
+		}
+		for i, header := range headers {
+			area := strings.TrimSpace(header)
+			if i == dateIdx || area == "" || field(record, i) == "" {
+				continue
+			}
+			value, err := ParseNumber(field(record, i), c.opts.Decimal, c.opts.Thousands)
+			if err != nil {
+				c.skip(row, "invalid value '%s' for %s: %v", field(record, i), area, err)
+				continue
+			}
+			c.add(area, p, value)
+		}
+	}
+	return nil
+}
+
+// ParseDateFormat parses a date into its month. The format is one of
+// DateFormats, a Go time layout, or "" to try common formats.
+func ParseDateFormat(s string, format string) (Period, error) {
+	s = strings.Trim(strings.TrimSpace(s), "\"")
+	layouts := autoDateLayouts
+	if format != "" {
+		layout, ok := DateFormats[strings.ToUpper(format)]
+		if !ok {
+			layout = format
+		}
+		layouts = []string{layout}
+	}
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return Period{Year: t.Year(), Month: int(t.Month())}, nil
+		}
+	}
+	if format != "" {
+		return Period{}, fmt.Errorf("date does not match format %s", format)
+	}
+	return Period{}, fmt.Errorf("unrecognized date format")
+}
+
+// ParseNumber parses a number with the given decimal and thousands separators.
+// If both are zero they are detected: of '.' and ',' together, the last one is
+// the decimal separator. A separator that appears alone is the thousands
+// separator if it appears more than once, or for ',' if exactly three digits
+// follow it, as in "1,234"; otherwise it is the decimal separator, so "101.125"
+// keeps its decimals. Spaces and apostrophes are always removed as thousands
+// separators.
+func ParseNumber(s string, decimal rune, thousands rune) (float64, error) {
+	s = strings.Trim(strings.TrimSpace(s), "\"")
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "'", "").Replace(s)
+	if decimal == 0 {
+		switch {
+		case thousands == '.':
+			decimal = ','
+		case thousands != 0:
+			decimal = '.'
+		default:
+			decimal, thousands = detectSeparators(s)
+		}
+	}
+	if thousands != 0 {
+		s = strings.ReplaceAll(s, string(thousands), "")
+	}
+	if decimal != '.' {
+		s = strings.ReplaceAll(s, string(decimal), ".")
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// detectSeparators returns the decimal and thousands separators of the number s.
+func detectSeparators(s string) (decimal, thousands rune) {
+	lastComma, lastDot := strings.LastIndex(s, ","), strings.LastIndex(s, ".")
+	switch {
+	case lastComma >= 0 && lastDot >= 0:
+		if lastComma > lastDot {
+			return ',', '.'
+		}
+		return '.', ','
+	case lastComma >= 0:
+		if strings.Count(s, ",") > 1 || len(s)-lastComma-1 == 3 {
+			return '.', ','
+		}
+		return ',', '.'
+	case strings.Count(s, ".") > 1:
+		return ',', '.'
+	}
+	return '.', ','
+}
+
+// parseMonthName recognizes month column headers such as "Jan", "January", "01", "1" or "M01".
+func parseMonthName(header string) (int, bool) {
+	h := strings.ToLower(strings.TrimSpace(header))
+	for month := 1; month <= 12; month++ {
+		name := strings.ToLower(time.Month(month).String())
+		if h == name || h == name[:3] {
+			return month, true
+		}
+	}
+	month, err := strconv.Atoi(strings.TrimPrefix(h, "m"))
+	if err != nil || month < 1 || month > 12 {
+		return 0, false
+	}
+	return month, true
+}
@@ -0,0 +1,176 @@
+// csv_test.go
+package inflation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDateFormat(t *testing.T) {
+	tests := []struct {
+		input       string
+		format      string
+		expected    Period
+		expectError bool
+	}{
+		{"2024-03", "", Period{2024, 3}, false},
+		{"2024-03-17", "", Period{2024, 3}, false},
+		{"03/2024", "", Period{2024, 3}, false},
+		{"2024M03", "", Period{2024, 3}, false},
+		{"202403", "", Period{2024, 3}, false},
+		{"Mar 2024", "", Period{2024, 3}, false},
+		{"17/03/2024", "DD/MM/YYYY", Period{2024, 3}, false},
+		{"03/17/2024", "MM/DD/YYYY", Period{2024, 3}, false},
+		{"2024M03", "YYYYMMM", Period{2024, 3}, false},
+		{"2024-03", "MM/YYYY", Period{}, true},
+		{"17/03/2024", "", Period{}, true}, // Ambiguous without a format
+		{"March", "", Period{}, true},
+	}
+
+	for _, tt := range tests {
+		p, err := ParseDateFormat(tt.input, tt.format)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("Expected error for date '%s' with format '%s', but got %s", tt.input, tt.format, p)
+			}
+		} else if err != nil {
+			t.Errorf("Did not expect error for date '%s' with format '%s', but got: %v", tt.input, tt.format, err)
+		} else if p != tt.expected {
+			t.Errorf("For date '%s' with format '%s', expected %s, got %s", tt.input, tt.format, tt.expected, p)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		input     string
+		decimal   rune
+		thousands rune
+		expected  float64
+	}{
+		{"101.5", 0, 0, 101.5},
+		{"101,5", 0, 0, 101.5},
+		{"\"101,5\"", 0, 0, 101.5},
+		{"1,234.5", 0, 0, 1234.5},
+		{"1.234,5", 0, 0, 1234.5},
+		{"1 234,5", 0, 0, 1234.5},
+		{"1'234.5", 0, 0, 1234.5},
+		{"1,234", 0, 0, 1234},
+		{"-1,234", 0, 0, -1234},
+		{"1,234,567", 0, 0, 1234567},
+		{"1,234,567.5", 0, 0, 1234567.5},
+		{"1.234.567", 0, 0, 1234567},
+		{"1.234.567,5", 0, 0, 1234567.5},
+		{"1,23", 0, 0, 1.23},
+		{"1,2345", 0, 0, 1.2345},
+		{"101.125", 0, 0, 101.125},
+		{"1,234", 0, ',', 1234},
+		{"1.234", ',', '.', 1234},
+	}
+
+	for _, tt := range tests {
+		value, err := ParseNumber(tt.input, tt.decimal, tt.thousands)
+		if err != nil {
+			t.Errorf("Did not expect error for '%s', but got: %v", tt.input, err)
+		} else if !floatsAlmostEqual(value, tt.expected) {
+			t.Errorf("For '%s', expected %.6f, got %.6f", tt.input, tt.expected, value)
+		}
+	}
+}
+
+func TestReadCSV_Long(t *testing.T) {
+	input := "Stand;Monat;Index\n" +
+		"Quelle: Destatis;;\n" +
+		"DE;01/2024;\"1.017,5\"\n" +
+		"DE;02/2024;1.020,1\n" +
+		"DE;13/2024;1.021,0\n" +
+		"FR;01/2024;119,25\n"
+
+	result, err := ReadCSV(strings.NewReader(input), CSVOptions{
+		Delimiter:     ';',
+		DateColumn:    "Monat",
+		ValueColumn:   "Index",
+		CountryColumn: "Stand",
+		DateFormat:    "MM/YYYY",
+		Decimal:       ',',
+		Thousands:     '.',
+	})
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+
+	if len(result.Series) != 2 || result.Observations() != 3 {
+		t.Fatalf("Expected 2 series with 3 observations, got %d series with %d", len(result.Series), result.Observations())
+	}
+	de := result.Series[0]
+	if de.Area != "DE" {
+		t.Errorf("Expected first series for DE, got %s", de.Area)
+	}
+	value, ok := de.Series.Lookup(Period{Year: 2024, Month: 1})
+	if !ok || !floatsAlmostEqual(value, 1017.5) {
+		t.Errorf("Expected 1017.5 for DE 2024-01, got %.6f (found=%v)", value, ok)
+	}
+
+	// The source line and the invalid month are reported
+	if len(result.Skipped) != 2 || result.Skipped[0].Line != 2 || result.Skipped[1].Line != 5 {
+		t.Errorf("Expected lines 2 and 5 to be skipped, got %+v", result.Skipped)
+	}
+}
+
+func TestReadCSV_Defaults(t *testing.T) {
+	// The original import format: date and value columns, comma decimals
+	input := "date,value\n2024-01-01,\"123,4\"\n2024-02-01,124.1\n"
+
+	result, err := ReadCSV(strings.NewReader(input), CSVOptions{Area: "US"})
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(result.Series) != 1 || result.Series[0].Area != "US" || result.Observations() != 2 {
+		t.Fatalf("Unexpected result: %+v", result)
+	}
+	value, _ := result.Series[0].Series.Lookup(Period{Year: 2024, Month: 1})
+	if !floatsAlmostEqual(value, 123.4) {
+		t.Errorf("Expected 123.4 for 2024-01, got %.6f", value)
+	}
+
+	_, err = ReadCSV(strings.NewReader("month,level\n2024-01,1\n"), CSVOptions{})
+	if err == nil {
+		t.Errorf("Expected error for missing date and value columns, but got none")
+	}
+}
+
+func TestReadCSV_WideMonths(t *testing.T) {
+	input := "Year,Jan,Feb,Mar,Apr,May,Jun,Jul,Aug,Sep,Oct,Nov,Dec,Annual\n" +
+		"2023,299.170,300.840,301.836,303.363,304.127,305.109,305.691,307.026,307.789,307.671,307.051,306.746,304.702\n" +
+		"2024,308.417,310.326,,,,,,,,,,,\n"
+
+	result, err := ReadCSV(strings.NewReader(input), CSVOptions{Layout: CSVWideMonths, Area: "US"})
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if result.Observations() != 14 || len(result.Skipped) != 0 {
+		t.Fatalf("Expected 14 observations and no skipped rows, got %d and %+v", result.Observations(), result.Skipped)
+	}
+	last, _ := result.Series[0].Series.Last()
+	if last.Period != (Period{Year: 2024, Month: 2}) || !floatsAlmostEqual(last.Value, 310.326) {
+		t.Errorf("Expected last observation 2024-02 = 310.326, got %s = %.3f", last.Period, last.Value)
+	}
+}
+
+func TestReadCSV_WideCountries(t *testing.T) {
+	input := "TIME\tDE\tGR\tCH\n2024M01\t123.7\t115.61\t107.1\n2024M02\t124.5\t\t107.7\n2024M03\t125.2\t118.41\tn/a\n"
+
+	result, err := ReadCSV(strings.NewReader(input), CSVOptions{Layout: CSVWideCountries, Delimiter: '\t'})
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	if len(result.Series) != 3 || result.Observations() != 7 {
+		t.Fatalf("Expected 3 series with 7 observations, got %d series with %d", len(result.Series), result.Observations())
+	}
+	if result.Series[1].Area != "GR" || result.Series[1].Series.Len() != 2 {
+		t.Errorf("Expected 2 observations for GR, got %v", result.Series[1])
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Line != 4 {
+		t.Errorf("Expected the CH value on line 4 to be skipped, got %+v", result.Skipped)
+	}
+}
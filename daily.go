@@ -0,0 +1,108 @@
+// inflation/daily.go
+package inflation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DailyMethod describes how DayIndexLevel derives the level of a day.
+const DailyMethod = "linear interpolation by day between the levels of the two nearest months, each positioned at the middle of its month and the day at its start"
+
+// ParseDay parses a day in YYYY-MM-DD format, checked against the calendar.
+// It reports false without an error if s does not have that format, so it
+// can be parsed as a span instead, see ParseSpan.
+func ParseDay(s string) (time.Time, bool, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != len("2006-01-02") || s[4] != '-' || s[7] != '-' {
+		return time.Time{}, false, nil
+	}
+	day, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, true, fmt.Errorf("invalid date '%s'", s)
+	}
+	return day, true, nil
+}
+
+// DayIndexLevel returns the index level of a day. Monthly levels describe the
+// middle of their month, so the level of a day is interpolated linearly by
+// time between the level of its month and that of the previous month (in the
+// first half of the month) or the next month (in the second half). Both months
+// must be available, after gap filling and projection. The result has
+// Months 2 and its Method set to DailyMethod.
+func (d *Data) DayIndexLevel(country string, date time.Time, opts ...QueryOption) (IndexValue, error) {
+	_, s, err := d.countrySeries(country, opts...)
+	if err != nil {
+		return IndexValue{}, err
+	}
+	return s.dayValue(country, date)
+}
+
+// CompareDates adjusts a price for inflation between two days, using the
+// levels of DayIndexLevel, see Compare. The time between the days is measured
+// from the start of each day.
+func (d *Data) CompareDates(country string, from, to time.Time, price float64, opts ...QueryOption) (Comparison, error) {
+	c, s, err := d.countrySeries(country, opts...)
+	if err != nil {
+		return Comparison{}, err
+	}
+	fromValue, err := s.dayValue(country, from)
+	if err != nil {
+		return Comparison{}, err
+	}
+	toValue, err := s.dayValue(country, to)
+	if err != nil {
+		return Comparison{}, err
+	}
+	result, err := newComparison(c, newQuery(opts).series, fromValue, toValue, price)
+	result.Projection = s.projectionName(fromValue, toValue)
+	return result, err
+}
+
+// dayValue interpolates the level of a day between the two nearest months.
+func (s indexSeries) dayValue(country string, date time.Time) (IndexValue, error) {
+	year, month, day := date.Date()
+	p := Period{Year: year, Month: int(month)}
+	a, b := p.AddMonths(-1), p
+	if 2*(day-1) >= daysIn(year, int(month)) {
+		a, b = p, p.AddMonths(1)
+	}
+
+	from, err := s.indexValue(country, a.Year, a.Month)
+	if err != nil {
+		return IndexValue{}, fmt.Errorf("cannot interpolate %s: %v", date.Format("2006-01-02"), err)
+	}
+	to, err := s.indexValue(country, b.Year, b.Month)
+	if err != nil {
+		return IndexValue{}, fmt.Errorf("cannot interpolate %s: %v", date.Format("2006-01-02"), err)
+	}
+
+	// Position the start of the day and both months in days since the middle of month a
+	t := float64(day-1) - midMonth(a)
+	span := float64(daysIn(a.Year, a.Month)) - midMonth(a) + midMonth(b)
+	if p == b {
+		t += float64(daysIn(a.Year, a.Month))
+	}
+	w := t / span
+
+	return IndexValue{
+		Period:       p,
+		Day:          day,
+		Value:        from.Value + (to.Value-from.Value)*w,
+		Months:       2,
+		Projected:    from.Projected + to.Projected,
+		Interpolated: append(from.Interpolated, to.Interpolated...),
+		Method:       fmt.Sprintf("%s (%s and %s)", DailyMethod, a, b),
+	}, nil
+}
+
+// midMonth returns the middle of a month in days since its start.
+func midMonth(p Period) float64 {
+	return float64(daysIn(p.Year, p.Month)) / 2
+}
+
+// daysIn returns the number of days of a month.
+func daysIn(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
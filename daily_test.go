@@ -0,0 +1,77 @@
+// daily_test.go
+package inflation
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func createDailyTestData() Data {
+	return Data{
+		Countries: []Country{
+			{
+				Name: "Testland",
+				Code: "TL",
+				Inflation: map[string]map[string]float64{
+					"2015": {"01": 100, "02": 128, "03": 159},
+				},
+			},
+		},
+	}
+}
+
+func TestDayIndexLevel(t *testing.T) {
+	data := createDailyTestData()
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	tests := []struct {
+		date     string
+		expected float64
+		wantErr  bool
+	}{
+		// February has 28 days, so its middle is the start of the 15th
+		{"2015-02-15", 128, false},
+		// 14.5 of the 29.5 days from mid-January to mid-February
+		{"2015-01-31", 100 + 28*14.5/29.5, false},
+		// 1 of the 29.5 days from mid-February to mid-March
+		{"2015-02-16", 128 + 31*1/29.5, false},
+		// No December 2014 or April 2015 to interpolate with
+		{"2015-01-10", 0, true},
+		{"2015-03-20", 0, true},
+	}
+	for _, tt := range tests {
+		level, err := data.DayIndexLevel("TL", date(tt.date))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for %s, but got none", tt.date)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Did not expect error for %s, but got: %v", tt.date, err)
+			continue
+		}
+		if !floatsAlmostEqual(level.Value, tt.expected) {
+			t.Errorf("Expected %.4f for %s, but got %.4f", tt.expected, tt.date, level.Value)
+		}
+		if level.Day != date(tt.date).Day() || level.Months != 2 || !strings.HasPrefix(level.Method, DailyMethod) {
+			t.Errorf("Expected a daily level with its method for %s, but got %+v", tt.date, level)
+		}
+	}
+
+	result, err := data.CompareDates("TL", date("2015-01-31"), date("2015-02-15"), 100)
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if !floatsAlmostEqual(result.Price, 100*128/(100+28*14.5/29.5)) {
+		t.Errorf("Expected the price between the daily levels, but got %.4f", result.Price)
+	}
+	// From the start of January 31 to the start of February 15
+	if !floatsAlmostEqual(result.Years, (1.0/31+14.0/28)/12) {
+		t.Errorf("Expected %.6f years, but got %.6f", (1.0/31+14.0/28)/12, result.Years)
+	}
+}
@@ -2,10 +2,8 @@
 package inflation
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"io"
-	"net/http"
 	"os"
 	"strings"
 )
@@ -13,6 +11,12 @@ import (
 // Data holds the inflation rates for multiple countries.
 type Data struct {
 	Countries []Country `json:"countries"`
+
+	// Gaps is how queries treat missing months, GapError if not set.
+	Gaps GapPolicy `json:"-"`
+	// PartialYears lets annual averages use the months available; by default
+	// queries refuse the average of a year with fewer than 12 months.
+	PartialYears bool `json:"-"`
 }
 
 // Country represents a country's inflation information.
@@ -20,20 +24,45 @@ type Country struct {
 	Name      string                        `json:"name"`
 	Aliases   []string                      `json:"aliases"`
 	Code      string                        `json:"code"`
-	BaseYear  int                           `json:"base_year"` // HICP Base Year
-	Inflation map[string]map[string]float64 `json:"inflation"` // Year -> Month -> Rate
+	BaseYear  int                           `json:"base_year"`         // HICP Base Year
+	Inflation map[string]map[string]float64 `json:"inflation"`         // Year -> Month -> Index level, see Series
+	Splices   []SpliceInfo                  `json:"splices,omitempty"` // Where other series were joined, see Splice
+	// SubIndices holds indices of categories of consumption keyed by COICOP code, see SeriesFor.
+	SubIndices map[string]SubIndex `json:"subindices,omitempty"`
+	// Regions holds sub-national series, e.g. US census regions, see GetCountry.
+	Regions []Country `json:"regions,omitempty"`
 }
 
 // Loader is responsible for loading inflation data.
 type Loader struct {
 	Data       Data
+	Cache      *Cache // Cache used when caching is requested; DefaultCache if nil
 	dataLoaded bool
 }
 
 // LoadData loads the inflation data from the provided source.
 // It accepts a 'cache' boolean to decide whether to cache the data if fetched from a URL.
+// An empty source loads the embedded default data.
 func (l *Loader) LoadData(source string, cache bool) error {
-	data, err := LoadInflationData(source, cache)
+	var opts []LoadOption
+	if cache {
+		c := l.Cache
+		if c == nil {
+			var err error
+			c, err = DefaultCache()
+			if err != nil {
+				return err
+			}
+		}
+		opts = append(opts, WithCache(c))
+	}
+	return l.LoadDataContext(context.Background(), source, opts...)
+}
+
+// LoadDataContext loads the inflation data from the provided source like
+// LoadData, configured by options for remote sources.
+func (l *Loader) LoadDataContext(ctx context.Context, source string, opts ...LoadOption) error {
+	data, err := LoadInflationDataContext(ctx, source, opts...)
 	if err != nil {
 		return err
 	}
@@ -43,34 +72,42 @@ func (l *Loader) LoadData(source string, cache bool) error {
 }
 
 // LoadInflationData loads inflation data from a local file or a URL.
+// If cache is true, URLs are fetched through DefaultCache.
+// An empty source returns the embedded default data, see Default.
 func LoadInflationData(source string, cache bool) (Data, error) {
-	var data Data
-
-	if isURL(source) {
-		// Fetch from URL
-		resp, err := http.Get(source)
+	var c *Cache
+	if cache {
+		var err error
+		c, err = DefaultCache()
 		if err != nil {
-			return data, err
+			return Data{}, err
 		}
-		defer resp.Body.Close()
+	}
+	return LoadInflationDataCached(source, c)
+}
 
-		if resp.StatusCode != http.StatusOK {
-			return data, errors.New("failed to fetch inflation data from URL")
-		}
+// LoadInflationDataCached loads inflation data like LoadInflationData, fetching
+// URLs through the given cache. A nil cache always downloads.
+func LoadInflationDataCached(source string, cache *Cache) (Data, error) {
+	return LoadInflationDataContext(context.Background(), source, WithCache(cache))
+}
+
+// LoadInflationDataContext loads inflation data from a local file or a URL.
+// The context and options control how URLs are fetched.
+// An empty source returns the embedded default data, see Default.
+func LoadInflationDataContext(ctx context.Context, source string, opts ...LoadOption) (Data, error) {
+	var data Data
+
+	if source == "" {
+		return Default()
+	}
 
-		body, err := io.ReadAll(resp.Body)
+	if isURL(source) {
+		body, err := newLoadConfig(opts).fetch(ctx, source)
 		if err != nil {
 			return data, err
 		}
 
-		// Optionally cache the data
-		if cache {
-			err = os.WriteFile("inflationratelist.json", body, 0644)
-			if err != nil {
-				return data, err
-			}
-		}
-
 		err = json.Unmarshal(body, &data)
 		if err != nil {
 			return data, err
@@ -90,9 +127,37 @@ func LoadInflationData(source string, cache bool) (Data, error) {
 		}
 	}
 
+	err := data.Validate()
+	if err != nil {
+		return data, err
+	}
+
 	return data, nil
 }
 
+// Validate checks that every country's inflation keys are valid years and months.
+func (d *Data) Validate() error {
+	return validateCountries(d.Countries)
+}
+
+// validateCountries checks the series of countries and their regions.
+func validateCountries(countries []Country) error {
+	for i := range countries {
+		c := &countries[i]
+		for _, code := range c.SeriesCodes() {
+			_, err := c.SeriesFor(code)
+			if err != nil {
+				return err
+			}
+		}
+		err := validateCountries(c.Regions)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // isURL checks if the source string is a URL.
 func isURL(source string) bool {
 	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
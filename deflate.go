@@ -0,0 +1,166 @@
+// inflation/deflate.go
+package inflation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Amount is a nominal amount of a year, month or day, see Deflate.
+type Amount struct {
+	Period Period  `json:"period"`        // Month is 0 for an annual amount
+	Day    int     `json:"day,omitempty"` // Day of the month, 0 for a monthly or annual amount
+	Value  float64 `json:"value"`
+}
+
+// RealValue is a nominal amount expressed in the prices of a reference period.
+type RealValue struct {
+	Period  Period     `json:"period"`        // Month is 0 for an annual amount
+	Day     int        `json:"day,omitempty"` // Day of the month, 0 for a monthly or annual amount
+	Nominal float64    `json:"nominal"`       // Amount in the prices of Period
+	Real    float64    `json:"real"`          // Amount in the prices of the reference period
+	Index   IndexValue `json:"index"`         // Index level the amount was deflated with
+}
+
+// Deflate converts nominal amounts of a country to real amounts in the prices
+// of a reference period (the average of refYear if refMonth is 0). A monthly
+// amount uses the index of its month and an amount of a day the level
+// interpolated as by DayIndexLevel where available, and the annual average of
+// its year otherwise (which needs Data.PartialYears if the year lacks a
+// month); an amount with month 0 uses the annual average. The index used is
+// reported with every value.
+// It uses the headline index unless another series is selected with WithSeries.
+func (d *Data) Deflate(country string, amounts []Amount, refYear, refMonth int, opts ...QueryOption) ([]RealValue, error) {
+	return d.DeflateSpan(country, amounts, spanOf(refYear, refMonth), opts...)
+}
+
+// DeflateSpan converts nominal amounts like Deflate, in the prices of the
+// average of a reference span such as a quarter or fiscal year.
+func (d *Data) DeflateSpan(country string, amounts []Amount, ref Span, opts ...QueryOption) ([]RealValue, error) {
+	_, s, err := d.countrySeries(country, opts...)
+	if err != nil {
+		return nil, err
+	}
+	refValue, err := s.spanValue(country, ref)
+	if err != nil {
+		return nil, fmt.Errorf("reference period: %w", err)
+	}
+	if refValue.Value == 0 {
+		return nil, fmt.Errorf("cannot deflate to a zero index level")
+	}
+
+	values := make([]RealValue, len(amounts))
+	for i, amount := range amounts {
+		values[i], err = s.deflate(country, amount, refValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// deflate converts one amount to the prices of the reference index level.
+func (s indexSeries) deflate(country string, amount Amount, ref IndexValue) (RealValue, error) {
+	var index IndexValue
+	var err error
+	if amount.Day != 0 {
+		index, err = s.dayValue(country, time.Date(amount.Period.Year, time.Month(amount.Period.Month), amount.Day, 0, 0, 0, 0, time.UTC))
+	} else {
+		index, err = s.indexValue(country, amount.Period.Year, amount.Period.Month)
+	}
+	if err != nil && amount.Period.Month != 0 {
+		// Fall back to the annual average if a month is missing
+		index, err = s.indexValue(country, amount.Period.Year, 0)
+	}
+	if err != nil {
+		return RealValue{}, err
+	}
+	if index.Value == 0 {
+		return RealValue{}, fmt.Errorf("cannot deflate %s with a zero index level", amount.Period)
+	}
+	return RealValue{
+		Period:  amount.Period,
+		Day:     amount.Day,
+		Nominal: amount.Value,
+		Real:    amount.Value * ref.Value / index.Value,
+		Index:   index,
+	}, nil
+}
+
+// ReadAmounts reads a CSV file with date and amount columns, matched
+// case-insensitively in the header row, for Deflate. Dates are YYYY, YYYY-MM
+// or YYYY-MM-DD as for CompareBatch; amounts are parsed with ParseNumber.
+func ReadAmounts(r io.Reader, delimiter, decimal, thousands rune) ([]Amount, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	if delimiter != 0 {
+		reader.Comma = delimiter
+	}
+	if decimal == 0 {
+		decimal = '.'
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty CSV file")
+	}
+	if err != nil {
+		return nil, err
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	dateIdx, amountIdx := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case BatchDateColumn:
+			dateIdx = i
+		case BatchAmountColumn:
+			amountIdx = i
+		}
+	}
+	if dateIdx < 0 || amountIdx < 0 {
+		return nil, fmt.Errorf("CSV file needs '%s' and '%s' columns", BatchDateColumn, BatchAmountColumn)
+	}
+
+	var amounts []Amount
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		if dateIdx >= len(record) || amountIdx >= len(record) {
+			return nil, fmt.Errorf("line %d: missing date or amount", line)
+		}
+		date, err := parseBatchDate(strings.TrimSpace(record[dateIdx]), 0)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		var a Amount
+		switch {
+		case !date.day.IsZero():
+			a.Period = Period{Year: date.day.Year(), Month: int(date.day.Month())}
+			a.Day = date.day.Day()
+		case date.span.IsYear():
+			a.Period = Period{Year: date.span.From.Year}
+		case date.span.Months() == 1:
+			a.Period = date.span.From
+		default:
+			return nil, fmt.Errorf("line %d: amounts need a year, month or day, not %s", line, date.span)
+		}
+		a.Value, err = ParseNumber(record[amountIdx], decimal, thousands)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount '%s'", line, record[amountIdx])
+		}
+		amounts = append(amounts, a)
+	}
+	return amounts, nil
+}
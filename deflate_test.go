@@ -0,0 +1,90 @@
+// deflate_test.go
+package inflation
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeflate(t *testing.T) {
+	data := createTestData()
+	// Remove a month to test the fallback to the annual average
+	delete(data.Countries[1].Inflation["2018"], "06")
+	if _, err := data.Deflate("DE", []Amount{{Period: Period{2018, 6}, Value: 100}}, 2015, 0); err == nil {
+		t.Errorf("Expected error for a missing month of an incomplete year, but got none")
+	}
+	data.PartialYears = true
+
+	amounts := []Amount{
+		{Period: Period{2015, 1}, Value: 100},
+		{Period: Period{2018, 6}, Value: 100},
+		{Period: Period{2018, 0}, Value: 100},
+		{Period: Period{2015, 3}, Day: 20, Value: 100},
+	}
+	values, err := data.Deflate("DE", amounts, 2015, 0)
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	ref, _ := data.YearInflation("DE", 2015, 0)
+	jan, _ := data.YearInflation("DE", 2015, 1)
+	avg2018, _ := data.YearInflation("DE", 2018, 0)
+
+	if !floatsAlmostEqual(values[0].Real, 100*ref/jan) || values[0].Index.Average {
+		t.Errorf("Expected the monthly index for 2015-01, but got %+v", values[0])
+	}
+	if !floatsAlmostEqual(values[1].Real, 100*ref/avg2018) || !values[1].Index.Average {
+		t.Errorf("Expected the annual average for the missing month 2018-06, but got %+v", values[1])
+	}
+	if !floatsAlmostEqual(values[2].Real, 100*ref/avg2018) || values[2].Nominal != 100 {
+		t.Errorf("Expected the annual average for 2018, but got %+v", values[2])
+	}
+	day, _ := data.DayIndexLevel("DE", time.Date(2015, 3, 20, 0, 0, 0, 0, time.UTC))
+	if !floatsAlmostEqual(values[3].Real, 100*ref/day.Value) || values[3].Day != 20 || values[3].Index.Day != 20 {
+		t.Errorf("Expected the interpolated level of 2015-03-20, but got %+v", values[3])
+	}
+
+	if _, err := data.Deflate("DE", []Amount{{Period: Period{2016, 1}, Value: 1}}, 2015, 0); err == nil {
+		t.Errorf("Expected error for a year without data, but got none")
+	}
+	if _, err := data.Deflate("DE", amounts, 2017, 0); err == nil {
+		t.Errorf("Expected error for a missing reference period, but got none")
+	}
+	if _, err := data.Deflate("XX", amounts, 2015, 0); err == nil {
+		t.Errorf("Expected error for unknown country, but got none")
+	}
+}
+
+func TestReadAmounts(t *testing.T) {
+	input := "\ufeffmonth;Date;Amount\nJan;2016-01;1.500,5\nYear;2018;100\nDay;2015-03-15;7\n"
+	amounts, err := ReadAmounts(strings.NewReader(input), ';', ',', '.')
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	expected := []Amount{{Period: Period{2016, 1}, Value: 1500.5}, {Period: Period{2018, 0}, Value: 100}, {Period: Period{2015, 3}, Day: 15, Value: 7}}
+	if len(amounts) != len(expected) {
+		t.Fatalf("Expected %d amounts, but got %v", len(expected), amounts)
+	}
+	for i := range expected {
+		if amounts[i] != expected[i] {
+			t.Errorf("Expected %v, but got %v", expected[i], amounts[i])
+		}
+	}
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing amount column", "date,value\n2015,1\n"},
+		{"invalid date", "date,amount\n2015-13,1\n"},
+		{"invalid day", "date,amount\n2015-02-30,1\n"},
+		{"quarter", "date,amount\n2015-Q1,1\n"},
+		{"invalid amount", "date,amount\n2015,abc\n"},
+		{"empty file", ""},
+	}
+	for _, tt := range tests {
+		if _, err := ReadAmounts(strings.NewReader(tt.input), 0, 0, 0); err == nil {
+			t.Errorf("Expected error for %s, but got none", tt.name)
+		}
+	}
+}
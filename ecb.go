@@ -0,0 +1,326 @@
+// inflation/ecb.go
+package inflation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ECBHeadline selects the monthly, non-adjusted overall HICP index series
+// (e.g. ICP.M.DE.N.000000.4.INX) from an ECB ICP export.
+var ECBHeadline = map[string]string{
+	"FREQ":       "M",
+	"ADJUSTMENT": "N",
+	"ICP_ITEM":   "000000",
+	"ICP_SUFFIX": "INX",
+}
+
+// MatchDimensions reports whether the series has every dimension value in filter.
+func (in ImportedSeries) MatchDimensions(filter map[string]string) bool {
+	for dim, value := range filter {
+		if in.Dimensions[dim] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadECBFile reads an ECB Data Portal export in SDMX-CSV or SDMX-JSON format.
+func ReadECBFile(path string) ([]ImportedSeries, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseECB(file)
+}
+
+// ParseECB reads an ECB Data Portal export, detecting SDMX-JSON or SDMX-CSV from the content.
+func ParseECB(r io.Reader) ([]ImportedSeries, error) {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return nil, fmt.Errorf("empty ECB export")
+		}
+		if b[0] == ' ' || b[0] == '\t' || b[0] == '\r' || b[0] == '\n' {
+			br.ReadByte()
+			continue
+		}
+		if b[0] == '{' {
+			return ParseECBJSON(br)
+		}
+		return ParseECBCSV(br)
+	}
+}
+
+// ParseECBCSV reads an SDMX-CSV export ("csvdata" format) of the ECB Data Portal.
+// Observations are grouped by series key; only monthly observations are kept.
+func ParseECBCSV(r io.Reader) ([]ImportedSeries, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDMX-CSV: %v", err)
+	}
+	if len(records) < 1 {
+		return nil, fmt.Errorf("SDMX-CSV file is empty")
+	}
+
+	headers := records[0]
+	if len(headers) > 0 {
+		headers[0] = strings.TrimPrefix(headers[0], "\ufeff")
+	}
+	col := make(map[string]int, len(headers))
+	for i, header := range headers {
+		col[strings.ToUpper(strings.TrimSpace(header))] = i
+	}
+	timeIdx, okTime := col["TIME_PERIOD"]
+	_, okValue := col["OBS_VALUE"]
+	if !okTime || !okValue {
+		return nil, fmt.Errorf("SDMX-CSV file must have 'TIME_PERIOD' and 'OBS_VALUE' columns")
+	}
+
+	// Dimensions are the columns before TIME_PERIOD, except KEY and DATAFLOW.
+	var dims []string
+	for _, header := range headers[:timeIdx] {
+		header = strings.ToUpper(strings.TrimSpace(header))
+		if header != "KEY" && header != "DATAFLOW" {
+			dims = append(dims, header)
+		}
+	}
+
+	bySeries := make(map[string]*ImportedSeries)
+	var order []string
+	for line, record := range records[1:] {
+		field := func(name string) string {
+			i, ok := col[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		dimensions := make(map[string]string, len(dims))
+		values := make([]string, len(dims))
+		for i, dim := range dims {
+			dimensions[dim] = field(dim)
+			values[i] = dimensions[dim]
+		}
+		key := field("KEY")
+		if key == "" {
+			key = strings.Join(values, ".")
+			if flow := field("DATAFLOW"); flow != "" {
+				key = dataflowID(flow) + "." + key
+			}
+		}
+
+		in, exists := bySeries[key]
+		if !exists {
+			in = &ImportedSeries{
+				Key:        key,
+				Area:       dimensions["REF_AREA"],
+				Name:       field("REF_AREA_NAME"),
+				BaseYear:   parseBaseYear(field("UNIT_INDEX_BASE")),
+				SubIndex:   ecbSubIndex(dimensions["ICP_ITEM"]),
+				Dimensions: dimensions,
+			}
+			bySeries[key] = in
+			order = append(order, key)
+		}
+
+		valueStr := field("OBS_VALUE")
+		if valueStr == "" || valueStr == "NaN" {
+			continue // Missing observation
+		}
+		period, err := ParsePeriod(record[timeIdx])
+		if err != nil {
+			continue // Not a monthly observation
+		}
+		value, err := strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OBS_VALUE '%s' on line %d: %v", valueStr, line+2, err)
+		}
+		in.Series = append(in.Series, Observation{Period: period, Value: value})
+	}
+
+	result := make([]ImportedSeries, 0, len(order))
+	for _, key := range order {
+		in := bySeries[key]
+		in.Series = sortSeries(in.Series)
+		result = append(result, *in)
+	}
+	return result, nil
+}
+
+// dataflowID extracts "ICP" from SDMX dataflow references such as "ECB:ICP(1.0)".
+func dataflowID(flow string) string {
+	if i := strings.Index(flow, ":"); i >= 0 {
+		flow = flow[i+1:]
+	}
+	if i := strings.Index(flow, "("); i >= 0 {
+		flow = flow[:i]
+	}
+	return flow
+}
+
+// sdmxJSON is the subset of the SDMX-JSON 1.0 data message used by the ECB Data Portal.
+type sdmxJSON struct {
+	DataSets []struct {
+		Series map[string]struct {
+			Attributes   []*int                       `json:"attributes"`
+			Observations map[string][]json.RawMessage `json:"observations"`
+		} `json:"series"`
+	} `json:"dataSets"`
+	Structure struct {
+		Links []struct {
+			Rel  string `json:"rel"`
+			Href string `json:"href"`
+		} `json:"links"`
+		Dimensions struct {
+			Series      []sdmxComponent `json:"series"`
+			Observation []sdmxComponent `json:"observation"`
+		} `json:"dimensions"`
+		Attributes struct {
+			Series []sdmxComponent `json:"series"`
+		} `json:"attributes"`
+	} `json:"structure"`
+}
+
+type sdmxComponent struct {
+	ID     string `json:"id"`
+	Values []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"values"`
+}
+
+// ParseECBJSON reads an SDMX-JSON export ("jsondata" format) of the ECB Data Portal.
+// Only monthly observations are kept.
+func ParseECBJSON(r io.Reader) ([]ImportedSeries, error) {
+	var msg sdmxJSON
+	err := json.NewDecoder(r).Decode(&msg)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDMX-JSON: %v", err)
+	}
+	if len(msg.DataSets) == 0 {
+		return nil, fmt.Errorf("SDMX-JSON message has no data set")
+	}
+	if len(msg.Structure.Dimensions.Observation) != 1 {
+		return nil, fmt.Errorf("SDMX-JSON message must have a single observation dimension")
+	}
+	timeValues := msg.Structure.Dimensions.Observation[0].Values
+
+	flow := ""
+	for _, link := range msg.Structure.Links {
+		if link.Rel == "dataflow" {
+			// e.g. https://data-api.ecb.europa.eu/service/dataflow/ECB/ICP/1.0
+			parts := strings.Split(strings.TrimRight(link.Href, "/"), "/")
+			if len(parts) >= 2 {
+				flow = parts[len(parts)-2]
+			}
+		}
+	}
+
+	seriesDims := msg.Structure.Dimensions.Series
+	var result []ImportedSeries
+	for seriesKey, data := range msg.DataSets[0].Series {
+		indices := strings.Split(seriesKey, ":")
+		if len(indices) != len(seriesDims) {
+			return nil, fmt.Errorf("series key '%s' does not match %d dimensions", seriesKey, len(seriesDims))
+		}
+
+		in := ImportedSeries{Dimensions: make(map[string]string, len(seriesDims))}
+		values := make([]string, len(seriesDims))
+		for i, dim := range seriesDims {
+			idx, err := strconv.Atoi(indices[i])
+			if err != nil || idx < 0 || idx >= len(dim.Values) {
+				return nil, fmt.Errorf("invalid series key '%s'", seriesKey)
+			}
+			values[i] = dim.Values[idx].ID
+			in.Dimensions[dim.ID] = dim.Values[idx].ID
+			if dim.ID == "REF_AREA" {
+				in.Area = dim.Values[idx].ID
+				in.Name = dim.Values[idx].Name
+			}
+		}
+		in.SubIndex = ecbSubIndex(in.Dimensions["ICP_ITEM"])
+		in.Key = strings.Join(values, ".")
+		if flow != "" {
+			in.Key = flow + "." + in.Key
+		}
+
+		for i, attr := range msg.Structure.Attributes.Series {
+			if attr.ID != "UNIT_INDEX_BASE" || i >= len(data.Attributes) || data.Attributes[i] == nil {
+				continue
+			}
+			if idx := *data.Attributes[i]; idx >= 0 && idx < len(attr.Values) {
+				in.BaseYear = parseBaseYear(attr.Values[idx].Name)
+				if in.BaseYear == 0 {
+					in.BaseYear = parseBaseYear(attr.Values[idx].ID)
+				}
+			}
+		}
+
+		for obsKey, obs := range data.Observations {
+			idx, err := strconv.Atoi(obsKey)
+			if err != nil || idx < 0 || idx >= len(timeValues) {
+				return nil, fmt.Errorf("invalid observation key '%s' in series '%s'", obsKey, in.Key)
+			}
+			if len(obs) == 0 || bytes.Equal(obs[0], []byte("null")) {
+				continue // Missing observation
+			}
+			period, err := ParsePeriod(timeValues[idx].ID)
+			if err != nil {
+				continue // Not a monthly observation
+			}
+			var value float64
+			err = json.Unmarshal(obs[0], &value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid observation value %s in series '%s': %v", obs[0], in.Key, err)
+			}
+			in.Series = append(in.Series, Observation{Period: period, Value: value})
+		}
+		in.Series = sortSeries(in.Series)
+		result = append(result, in)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result, nil
+}
+
+// ecbSpecialAggregates maps the ICP_ITEM codes of special aggregates to the
+// codes Eurostat uses for them.
+var ecbSpecialAggregates = map[string]string{
+	"FOOD00": "FOOD",
+	"NRGY00": "NRG",
+	"SERV00": "SERV",
+	"GOODS0": "GD",
+	"XEF000": "TOT_X_NRG_FOOD",
+}
+
+// ecbSubIndex converts an ICP_ITEM code to a sub-index code: "" for the
+// headline index, CP01 for 010000, CP0111 for 011100 and so on.
+func ecbSubIndex(item string) string {
+	if item == "" || item == ECBHeadline["ICP_ITEM"] {
+		return ""
+	}
+	if code, ok := ecbSpecialAggregates[item]; ok {
+		return code
+	}
+	if strings.Trim(item, "0123456789") != "" {
+		return item
+	}
+	for len(item) > 2 && item[len(item)-1] == '0' {
+		item = item[:len(item)-1]
+	}
+	return "CP" + item
+}
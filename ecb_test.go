@@ -0,0 +1,93 @@
+// ecb_test.go
+package inflation
+
+import (
+	"testing"
+)
+
+func TestReadECBFile(t *testing.T) {
+	for _, path := range []string{"testdata/ecb_icp.csv", "testdata/ecb_icp.json"} {
+		t.Run(path, func(t *testing.T) {
+			imported, err := ReadECBFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read ECB export: %v", err)
+			}
+			if len(imported) != 3 {
+				t.Fatalf("Expected 3 series, got %d", len(imported))
+			}
+
+			var headline []ImportedSeries
+			for _, in := range imported {
+				if in.MatchDimensions(ECBHeadline) {
+					headline = append(headline, in)
+				}
+			}
+			if len(headline) != 2 {
+				t.Fatalf("Expected 2 headline index series, got %d", len(headline))
+			}
+
+			de := headline[0]
+			if de.Key != "ICP.M.DE.N.000000.4.INX" || de.Area != "DE" || de.BaseYear != 2015 {
+				t.Errorf("Unexpected series %s (area %s, base year %d)", de.Key, de.Area, de.BaseYear)
+			}
+			if de.Series.Len() != 6 {
+				t.Errorf("Expected 6 observations for DE, got %d", de.Series.Len())
+			}
+			value, ok := de.Series.Lookup(Period{Year: 2024, Month: 3})
+			if !ok || !floatsAlmostEqual(value, 125.2) {
+				t.Errorf("Expected 125.2 for DE 2024-03, got %.6f (found=%v)", value, ok)
+			}
+
+			gr := headline[1]
+			if gr.Area != "GR" || gr.Series.Len() != 5 {
+				t.Errorf("Expected 5 observations for GR, got %s with %d", gr.Area, gr.Series.Len())
+			}
+		})
+	}
+}
+
+func TestMergeSeries_ECB(t *testing.T) {
+	data := createTestData()
+	imported, err := ReadECBFile("testdata/ecb_icp.csv")
+	if err != nil {
+		t.Fatalf("Failed to read ECB export: %v", err)
+	}
+
+	var reports []MergeReport
+	for _, in := range imported {
+		if !in.MatchDimensions(ECBHeadline) {
+			continue
+		}
+		report, err := data.MergeSeries(in)
+		if err != nil {
+			t.Fatalf("Failed to merge series %s: %v", in.Key, err)
+		}
+		reports = append(reports, report)
+	}
+
+	// Germany exists and gets new periods; Greece is created
+	if reports[0].Country != "Germany" || reports[0].Created || len(reports[0].Added) != 6 {
+		t.Errorf("Unexpected report for Germany: %+v", reports[0])
+	}
+	if reports[1].Code != "GR" || !reports[1].Created || len(reports[1].Added) != 5 {
+		t.Errorf("Unexpected report for Greece: %+v", reports[1])
+	}
+
+	level, err := data.YearInflation("GR", 2024, 2)
+	if err != nil || !floatsAlmostEqual(level, 116.45) {
+		t.Errorf("Expected 116.45 for GR 2024-02, got %.6f (err=%v)", level, err)
+	}
+	level, err = data.YearInflation("DE", 2015, 4)
+	if err != nil || !floatsAlmostEqual(level, 0.08) {
+		t.Errorf("Expected existing value 0.08 for DE 2015-04 to be kept, got %.6f (err=%v)", level, err)
+	}
+
+	// Merging again changes nothing
+	report, err := data.MergeSeries(imported[0])
+	if err != nil {
+		t.Fatalf("Failed to merge series again: %v", err)
+	}
+	if len(report.Added) != 0 || len(report.Changed) != 0 || report.Unchanged != 6 {
+		t.Errorf("Expected no changes when merging again, got %+v", report)
+	}
+}
@@ -0,0 +1,60 @@
+// inflation/embed.go
+package inflation
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// defaultData is the repository's data/inflationratelist.json compiled into the library.
+//
+//go:embed data/inflationratelist.json
+var defaultData []byte
+
+// Version identifies a snapshot of inflation data.
+type Version struct {
+	SHA256    string `json:"sha256"`
+	Size      int    `json:"size"`
+	Countries int    `json:"countries"`
+	Latest    Period `json:"latest"` // Latest observation across all countries
+}
+
+// Default returns the inflation data embedded in the library.
+// It is used when no inflation list is given, and needs no file or network access.
+func Default() (Data, error) {
+	var data Data
+	err := json.Unmarshal(defaultData, &data)
+	if err != nil {
+		return data, err
+	}
+	err = data.Validate()
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// DefaultVersion describes the embedded snapshot returned by Default.
+func DefaultVersion() (Version, error) {
+	data, err := Default()
+	if err != nil {
+		return Version{}, err
+	}
+
+	sum := sha256.Sum256(defaultData)
+	version := Version{
+		SHA256:    hex.EncodeToString(sum[:]),
+		Size:      len(defaultData),
+		Countries: len(data.Countries),
+	}
+	for i := range data.Countries {
+		s, _ := data.Countries[i].Series() // Validated by Default
+		last, ok := s.Last()
+		if ok && version.Latest.Before(last.Period) {
+			version.Latest = last.Period
+		}
+	}
+	return version, nil
+}
@@ -0,0 +1,46 @@
+// embed_test.go
+package inflation
+
+import (
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	data, err := Default()
+	if err != nil {
+		t.Fatalf("Failed to load embedded default data: %v", err)
+	}
+
+	for _, query := range []string{"US", "GR", "CH"} {
+		if _, err := data.GetCountry(query); err != nil {
+			t.Errorf("Expected country '%s' in embedded data, but got: %v", query, err)
+		}
+	}
+
+	// An empty source falls back to the embedded data
+	loader := &Loader{}
+	err = loader.LoadData("", false)
+	if err != nil {
+		t.Fatalf("Failed to load data from empty source: %v", err)
+	}
+	if len(loader.Data.Countries) != len(data.Countries) {
+		t.Errorf("Expected %d countries from empty source, got %d", len(data.Countries), len(loader.Data.Countries))
+	}
+}
+
+func TestDefaultVersion(t *testing.T) {
+	version, err := DefaultVersion()
+	if err != nil {
+		t.Fatalf("Failed to describe embedded default data: %v", err)
+	}
+
+	if len(version.SHA256) != 64 {
+		t.Errorf("Expected a hex SHA-256 digest, got '%s'", version.SHA256)
+	}
+	if version.Size != len(defaultData) || version.Countries == 0 {
+		t.Errorf("Unexpected version %+v", version)
+	}
+	if version.Latest.Year == 0 {
+		t.Errorf("Expected a latest observation, got %s", version.Latest)
+	}
+}
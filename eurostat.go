@@ -0,0 +1,381 @@
+// inflation/eurostat.go
+package inflation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EurostatHeadline selects the monthly all-items HICP series of prc_hicp_midx.
+var EurostatHeadline = map[string]string{
+	"FREQ":   "M",
+	"COICOP": "CP00",
+}
+
+// eurostatCodes maps Eurostat country codes to the ISO codes used in Data.
+var eurostatCodes = map[string]string{
+	"EL": "GR",
+	"UK": "GB",
+}
+
+// eurostatNames are the country names used when a dataset has no labels, as in the TSV format.
+var eurostatNames = map[string]string{
+	"AT": "Austria", "BE": "Belgium", "BG": "Bulgaria", "CH": "Switzerland", "CY": "Cyprus",
+	"CZ": "Czechia", "DE": "Germany", "DK": "Denmark", "EE": "Estonia", "EL": "Greece",
+	"ES": "Spain", "FI": "Finland", "FR": "France", "HR": "Croatia", "HU": "Hungary",
+	"IE": "Ireland", "IS": "Iceland", "IT": "Italy", "LT": "Lithuania", "LU": "Luxembourg",
+	"LV": "Latvia", "MT": "Malta", "NL": "Netherlands", "NO": "Norway", "PL": "Poland",
+	"PT": "Portugal", "RO": "Romania", "SE": "Sweden", "SI": "Slovenia", "SK": "Slovakia",
+	"TR": "Türkiye", "UK": "United Kingdom", "US": "United States",
+}
+
+// eurostatAggregates are geo codes of country groups rather than countries.
+var eurostatAggregates = map[string]bool{
+	"EA": true, "EU": true, "EEA": true, "EFTA": true,
+}
+
+// ReadEurostatFile reads a Eurostat dataset such as prc_hicp_midx in JSON-stat or TSV format.
+func ReadEurostatFile(path string) ([]ImportedSeries, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseEurostat(file)
+}
+
+// ParseEurostat reads a Eurostat dataset, detecting JSON-stat or TSV from the content.
+func ParseEurostat(r io.Reader) ([]ImportedSeries, error) {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return nil, fmt.Errorf("empty Eurostat dataset")
+		}
+		if b[0] == ' ' || b[0] == '\t' || b[0] == '\r' || b[0] == '\n' {
+			br.ReadByte()
+			continue
+		}
+		if b[0] == '{' {
+			return ParseEurostatJSON(br)
+		}
+		return ParseEurostatTSV(br)
+	}
+}
+
+// newEurostatSeries creates an ImportedSeries for a geo code, mapping Eurostat
+// codes to ISO codes and deriving the base year from the unit (e.g. I15 -> 2015).
+// It returns false for aggregates such as EA20 or EU27_2020.
+func newEurostatSeries(key string, dims map[string]string, name string) (ImportedSeries, bool) {
+	geo := dims["GEO"]
+	letters := strings.TrimRightFunc(strings.SplitN(geo, "_", 2)[0], func(r rune) bool { return r >= '0' && r <= '9' })
+	if geo == "" || eurostatAggregates[letters] || letters != geo {
+		return ImportedSeries{}, false
+	}
+
+	if name == "" {
+		name = eurostatNames[geo]
+	}
+	in := ImportedSeries{
+		Key:        key,
+		Area:       geo,
+		Name:       name,
+		Aliases:    []string{geo},
+		BaseYear:   eurostatBaseYear(dims["UNIT"]),
+		Dimensions: dims,
+	}
+	if coicop := dims["COICOP"]; coicop != EurostatHeadline["COICOP"] {
+		in.SubIndex = coicop
+	}
+	if iso, ok := eurostatCodes[geo]; ok {
+		in.Area = iso
+		in.Aliases = []string{iso, geo}
+	}
+	return in, true
+}
+
+// eurostatBaseYear converts index units such as I15 or I96 to a base year.
+func eurostatBaseYear(unit string) int {
+	if len(unit) != 3 || unit[0] != 'I' {
+		return 0
+	}
+	yy, err := strconv.Atoi(unit[1:])
+	if err != nil {
+		return 0
+	}
+	if yy > 50 {
+		return 1900 + yy
+	}
+	return 2000 + yy
+}
+
+// parseEurostatPeriod parses monthly Eurostat periods in "2024-03" or "2024M03" format.
+func parseEurostatPeriod(s string) (Period, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 7 && s[4] == 'M' {
+		s = s[:4] + "-" + s[5:]
+	}
+	return ParsePeriod(s)
+}
+
+// ParseEurostatTSV reads a Eurostat dataset in the bulk download TSV format, e.g.
+//
+//	freq,unit,coicop,geo\TIME_PERIOD	2024-02 	2024-01
+//	M,I15,CP00,DE	124.5 	123.7 p
+//
+// Missing values (":") are skipped and flags after the values are ignored.
+func ParseEurostatTSV(r io.Reader) ([]ImportedSeries, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Eurostat TSV file is empty")
+	}
+	header := strings.Split(strings.TrimRight(scanner.Text(), "\r"), "\t")
+	dimHeader := strings.TrimPrefix(header[0], "\ufeff")
+	if i := strings.Index(dimHeader, "\\"); i >= 0 {
+		dimHeader = dimHeader[:i]
+	}
+	dims := strings.Split(dimHeader, ",")
+	for i := range dims {
+		dims[i] = strings.ToUpper(strings.TrimSpace(dims[i]))
+	}
+
+	periods := make([]*Period, len(header))
+	for i, h := range header[1:] {
+		p, err := parseEurostatPeriod(h)
+		if err == nil {
+			periods[i+1] = &p // Non-monthly columns stay nil
+		}
+	}
+
+	var result []ImportedSeries
+	line := 1
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		values := strings.Split(fields[0], ",")
+		if len(values) != len(dims) {
+			return nil, fmt.Errorf("line %d: expected %d dimensions, got %d", line, len(dims), len(values))
+		}
+		dimensions := make(map[string]string, len(dims))
+		for i, dim := range dims {
+			dimensions[dim] = strings.TrimSpace(values[i])
+		}
+
+		in, ok := newEurostatSeries(strings.Join(values, "."), dimensions, "")
+		if !ok {
+			continue
+		}
+		for i, field := range fields[1:] {
+			if i+1 >= len(periods) || periods[i+1] == nil {
+				continue
+			}
+			valueStr := strings.Fields(field)
+			if len(valueStr) == 0 || valueStr[0] == ":" {
+				continue // Missing observation
+			}
+			value, err := strconv.ParseFloat(valueStr[0], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid value '%s' for %s", line, field, periods[i+1])
+			}
+			in.Series = append(in.Series, Observation{Period: *periods[i+1], Value: value})
+		}
+		in.Series = sortSeries(in.Series)
+		result = append(result, in)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// jsonStatCategory is a JSON-stat dimension category; the index can be an
+// object (id -> position) or an array of ids.
+type jsonStatCategory struct {
+	Index json.RawMessage   `json:"index"`
+	Label map[string]string `json:"label"`
+}
+
+// ids returns the category ids ordered by position.
+func (c jsonStatCategory) ids() ([]string, error) {
+	var list []string
+	if json.Unmarshal(c.Index, &list) == nil {
+		return list, nil
+	}
+	var positions map[string]int
+	err := json.Unmarshal(c.Index, &positions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid category index: %v", err)
+	}
+	list = make([]string, len(positions))
+	for id, pos := range positions {
+		if pos < 0 || pos >= len(list) {
+			return nil, fmt.Errorf("invalid position %d for category '%s'", pos, id)
+		}
+		list[pos] = id
+	}
+	return list, nil
+}
+
+// jsonStat is the subset of a JSON-stat 2.0 dataset used for importing.
+type jsonStat struct {
+	Class     string          `json:"class"`
+	ID        []string        `json:"id"`
+	Size      []int           `json:"size"`
+	Value     json.RawMessage `json:"value"`
+	Dimension map[string]struct {
+		Category jsonStatCategory `json:"category"`
+	} `json:"dimension"`
+	Extension struct {
+		DatasetID string `json:"datasetId"`
+	} `json:"extension"`
+}
+
+// values returns the dataset values by flat position; the value can be an
+// object (position -> value) or an array with nulls for missing values.
+func (j jsonStat) values() (map[int]float64, error) {
+	values := make(map[int]float64)
+	var list []*float64
+	if json.Unmarshal(j.Value, &list) == nil {
+		for i, v := range list {
+			if v != nil {
+				values[i] = *v
+			}
+		}
+		return values, nil
+	}
+	var object map[string]*float64
+	err := json.Unmarshal(j.Value, &object)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON-stat values: %v", err)
+	}
+	for key, v := range object {
+		pos, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON-stat value position '%s'", key)
+		}
+		if v != nil {
+			values[pos] = *v
+		}
+	}
+	return values, nil
+}
+
+// ParseEurostatJSON reads a Eurostat dataset in JSON-stat 2.0 format, as
+// returned by the Eurostat statistics API.
+func ParseEurostatJSON(r io.Reader) ([]ImportedSeries, error) {
+	var ds jsonStat
+	err := json.NewDecoder(r).Decode(&ds)
+	if err != nil {
+		return nil, fmt.Errorf("error reading JSON-stat: %v", err)
+	}
+	if ds.Class != "dataset" || len(ds.ID) == 0 || len(ds.ID) != len(ds.Size) {
+		return nil, fmt.Errorf("JSON-stat file is not a dataset with matching 'id' and 'size'")
+	}
+
+	timeDim := -1
+	categories := make([][]string, len(ds.ID))
+	for i, id := range ds.ID {
+		dim, ok := ds.Dimension[id]
+		if !ok {
+			return nil, fmt.Errorf("JSON-stat dimension '%s' is not described", id)
+		}
+		categories[i], err = dim.Category.ids()
+		if err != nil {
+			return nil, fmt.Errorf("dimension '%s': %v", id, err)
+		}
+		if len(categories[i]) != ds.Size[i] {
+			return nil, fmt.Errorf("dimension '%s' has %d categories, expected %d", id, len(categories[i]), ds.Size[i])
+		}
+		if strings.EqualFold(id, "time") {
+			timeDim = i
+		}
+	}
+	if timeDim == -1 {
+		return nil, fmt.Errorf("JSON-stat dataset has no 'time' dimension")
+	}
+
+	values, err := ds.values()
+	if err != nil {
+		return nil, err
+	}
+
+	bySeries := make(map[string]*ImportedSeries)
+	skipped := make(map[string]bool)
+	positions := make([]int, 0, len(values))
+	for pos := range values {
+		positions = append(positions, pos)
+	}
+	sort.Ints(positions)
+
+	coords := make([]string, len(ds.ID))
+	for _, pos := range positions {
+		// Row-major decomposition of the flat position
+		rest := pos
+		for i := len(ds.ID) - 1; i >= 0; i-- {
+			coords[i] = categories[i][rest%ds.Size[i]]
+			rest /= ds.Size[i]
+		}
+		if rest != 0 {
+			return nil, fmt.Errorf("JSON-stat value position %d is out of range", pos)
+		}
+
+		period, err := parseEurostatPeriod(coords[timeDim])
+		if err != nil {
+			continue // Not a monthly observation
+		}
+		var keyParts []string
+		dims := make(map[string]string, len(ds.ID)-1)
+		for i, id := range ds.ID {
+			if i != timeDim {
+				keyParts = append(keyParts, coords[i])
+				dims[strings.ToUpper(id)] = coords[i]
+			}
+		}
+		key := strings.Join(keyParts, ".")
+		if ds.Extension.DatasetID != "" {
+			key = strings.ToLower(ds.Extension.DatasetID) + "." + key
+		}
+		if skipped[key] {
+			continue
+		}
+
+		in, exists := bySeries[key]
+		if !exists {
+			name := ""
+			if geo, ok := ds.Dimension["geo"]; ok {
+				name = geo.Category.Label[dims["GEO"]]
+			}
+			series, ok := newEurostatSeries(key, dims, name)
+			if !ok {
+				skipped[key] = true
+				continue
+			}
+			in = &series
+			bySeries[key] = in
+		}
+		in.Series = append(in.Series, Observation{Period: period, Value: values[pos]})
+	}
+
+	result := make([]ImportedSeries, 0, len(bySeries))
+	for _, in := range bySeries {
+		in.Series = sortSeries(in.Series)
+		result = append(result, *in)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result, nil
+}
@@ -0,0 +1,105 @@
+// eurostat_test.go
+package inflation
+
+import (
+	"testing"
+)
+
+func TestReadEurostatFile(t *testing.T) {
+	tests := []struct {
+		path           string
+		expectedSeries int
+	}{
+		// Aggregates such as EA20 are skipped
+		{"testdata/eurostat_prc_hicp_midx.json", 3},
+		{"testdata/eurostat_prc_hicp_midx.tsv", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			imported, err := ReadEurostatFile(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to read Eurostat dataset: %v", err)
+			}
+			if len(imported) != tt.expectedSeries {
+				t.Fatalf("Expected %d series, got %d", tt.expectedSeries, len(imported))
+			}
+
+			filter := map[string]string{"FREQ": "M", "COICOP": "CP00", "UNIT": "I15"}
+			byArea := make(map[string]ImportedSeries)
+			for _, in := range imported {
+				if in.MatchDimensions(filter) {
+					byArea[in.Area] = in
+				}
+			}
+			if len(byArea) != 3 {
+				t.Fatalf("Expected 3 headline series, got %d", len(byArea))
+			}
+
+			// Greece is published as EL and mapped to its ISO code
+			gr, ok := byArea["GR"]
+			if !ok {
+				t.Fatalf("Expected Greece to be mapped to GR")
+			}
+			if gr.BaseYear != 2015 || len(gr.Aliases) != 2 || gr.Aliases[1] != "EL" {
+				t.Errorf("Unexpected Greece series: base year %d, aliases %v", gr.BaseYear, gr.Aliases)
+			}
+			// The 2024-03 value is missing and the provisional flag is ignored
+			if gr.Series.Len() != 2 {
+				t.Errorf("Expected 2 observations for Greece, got %d", gr.Series.Len())
+			}
+			value, ok := gr.Series.Lookup(Period{Year: 2024, Month: 2})
+			if !ok || !floatsAlmostEqual(value, 116.45) {
+				t.Errorf("Expected 116.45 for GR 2024-02, got %.6f (found=%v)", value, ok)
+			}
+
+			de := byArea["DE"]
+			first, _ := de.Series.First()
+			if de.Series.Len() != 3 || first.Period != (Period{Year: 2024, Month: 1}) || !floatsAlmostEqual(first.Value, 123.7) {
+				t.Errorf("Unexpected DE series: %v", de.Series)
+			}
+		})
+	}
+}
+
+func TestMergeAll_Eurostat(t *testing.T) {
+	data := createTestData()
+	imported, err := ReadEurostatFile("testdata/eurostat_prc_hicp_midx.json")
+	if err != nil {
+		t.Fatalf("Failed to read Eurostat dataset: %v", err)
+	}
+
+	// A dry run reports the changes without applying them
+	reports, err := data.MergeAll(imported, true)
+	if err != nil {
+		t.Fatalf("Failed to merge Eurostat dataset: %v", err)
+	}
+	if len(reports) != 3 {
+		t.Fatalf("Expected 3 reports, got %d", len(reports))
+	}
+	if len(data.Countries) != 2 {
+		t.Errorf("Expected dry run to leave 2 countries, got %d", len(data.Countries))
+	}
+
+	reports, err = data.MergeAll(imported, false)
+	if err != nil {
+		t.Fatalf("Failed to merge Eurostat dataset: %v", err)
+	}
+	created := 0
+	for _, report := range reports {
+		if report.Created {
+			created++
+		}
+	}
+	if created != 2 || len(data.Countries) != 4 {
+		t.Errorf("Expected France and Greece to be created (4 countries), got %d created and %d countries", created, len(data.Countries))
+	}
+
+	greece, err := data.GetCountry("EL")
+	if err != nil {
+		t.Fatalf("Expected Greece to be found by its Eurostat code: %v", err)
+	}
+	if greece.Name != "Greece" || greece.Code != "GR" || greece.BaseYear != 2015 {
+		t.Errorf("Unexpected Greece entry: %+v", greece)
+	}
+}
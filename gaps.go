@@ -0,0 +1,104 @@
+// inflation/gaps.go
+package inflation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GapPolicy is how queries treat months missing between the first and the
+// last observation of a series, see Data.Gaps. Months before the first or
+// after the last observation are never filled; see WithProjection for those.
+type GapPolicy int
+
+const (
+	// GapError fails queries that need a missing month.
+	GapError GapPolicy = iota
+	// GapCarryForward uses the level of the last month before the gap.
+	GapCarryForward
+	// GapLinear interpolates linearly between the months around the gap.
+	GapLinear
+	// GapLogLinear interpolates with constant growth between the months
+	// around the gap, i.e. linearly on the logarithm of the index.
+	GapLogLinear
+)
+
+var gapPolicyNames = []string{"error", "carry-forward", "linear", "log-linear"}
+
+func (g GapPolicy) String() string {
+	if g < 0 || int(g) >= len(gapPolicyNames) {
+		return fmt.Sprintf("GapPolicy(%d)", int(g))
+	}
+	return gapPolicyNames[g]
+}
+
+// ParseGapPolicy parses the name of a gap policy: error, carry-forward,
+// linear or log-linear. An empty string is GapError.
+func ParseGapPolicy(s string) (GapPolicy, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "" {
+		return GapError, nil
+	}
+	for i, n := range gapPolicyNames {
+		if name == n {
+			return GapPolicy(i), nil
+		}
+	}
+	return GapError, fmt.Errorf("unknown gap policy '%s' (use %s)", s, strings.Join(gapPolicyNames, ", "))
+}
+
+// IncompleteError is returned for the average of a year or span with missing
+// months unless Data.PartialYears is set.
+type IncompleteError struct {
+	Country string // Country as queried
+	Span    Span   // Year or span averaged
+	Months  int    // Months with data
+}
+
+func (e *IncompleteError) Error() string {
+	return fmt.Sprintf("inflation data for %s is incomplete for country '%s' (%d of %d months)", e.Span, e.Country, e.Months, e.Span.Months())
+}
+
+// FillGaps returns s with the months missing between its first and last
+// observation filled according to the policy, and the periods that were
+// filled. With GapError, s is returned unchanged.
+func FillGaps(s Series, policy GapPolicy) (Series, []Period, error) {
+	if policy == GapError || s.Len() < 2 {
+		return s, nil, nil
+	}
+	if policy < 0 || int(policy) >= len(gapPolicyNames) {
+		return nil, nil, fmt.Errorf("unknown gap policy %v", policy)
+	}
+
+	var filled Series
+	var periods []Period
+	for i, o := range s {
+		if i > 0 {
+			prev := s[i-1]
+			n := prev.Period.MonthsUntil(o.Period)
+			for k := 1; k < n; k++ {
+				p := prev.Period.AddMonths(k)
+				filled = append(filled, Observation{Period: p, Value: fillValue(policy, prev.Value, o.Value, float64(k)/float64(n))})
+				periods = append(periods, p)
+			}
+		}
+		filled = append(filled, o)
+	}
+	return filled, periods, nil
+}
+
+// fillValue returns the level at fraction t of the way from one observation to
+// the next. Log-linear interpolation falls back to linear for levels that are
+// not positive.
+func fillValue(policy GapPolicy, from, to, t float64) float64 {
+	switch policy {
+	case GapCarryForward:
+		return from
+	case GapLogLinear:
+		if from > 0 && to > 0 {
+			return from * math.Pow(to/from, t)
+		}
+	}
+	return from + (to-from)*t
+}
@@ -0,0 +1,105 @@
+// gaps_test.go
+package inflation
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFillGaps(t *testing.T) {
+	s := Series{
+		{Period{2020, 1}, 100},
+		{Period{2020, 4}, 127.1},
+		{Period{2020, 5}, 130},
+	}
+
+	tests := []struct {
+		policy   GapPolicy
+		expected []float64 // 2020-02 and 2020-03
+	}{
+		{GapCarryForward, []float64{100, 100}},
+		{GapLinear, []float64{109.0333333, 118.0666667}},
+		{GapLogLinear, []float64{100 * math.Pow(1.271, 1.0/3), 100 * math.Pow(1.271, 2.0/3)}},
+	}
+	for _, tt := range tests {
+		filled, periods, err := FillGaps(s, tt.policy)
+		if err != nil {
+			t.Errorf("%v: Did not expect error, but got: %v", tt.policy, err)
+			continue
+		}
+		if filled.Len() != 5 || len(periods) != 2 || periods[0] != (Period{2020, 2}) || periods[1] != (Period{2020, 3}) {
+			t.Errorf("%v: Expected 2020-02 and 2020-03 to be filled, but got %v (%v)", tt.policy, periods, filled)
+			continue
+		}
+		for i, want := range tt.expected {
+			if math.Abs(filled[i+1].Value-want) > 1e-6 {
+				t.Errorf("%v: Expected %.6f for %s, but got %.6f", tt.policy, want, filled[i+1].Period, filled[i+1].Value)
+			}
+		}
+	}
+
+	filled, periods, err := FillGaps(s, GapError)
+	if err != nil || filled.Len() != 3 || len(periods) != 0 {
+		t.Errorf("Expected GapError to leave the series unchanged, but got %v, %v, %v", filled, periods, err)
+	}
+}
+
+func TestGapPolicy(t *testing.T) {
+	data := createTestData()
+	// US has no 2017; remove a month of 2016 to create a gap within a year
+	delete(data.Countries[0].Inflation["2016"], "02")
+
+	if _, err := data.IndexLevel("US", 2016, 2); err == nil {
+		t.Errorf("Expected error for a missing month with GapError, but got none")
+	}
+	var incomplete *IncompleteError
+	if _, err := data.IndexLevel("US", 2016, 0); !errors.As(err, &incomplete) || incomplete.Months != 11 {
+		t.Errorf("Expected IncompleteError for the average of an incomplete year, but got: %v", err)
+	}
+
+	data.PartialYears = true
+	level, err := data.IndexLevel("US", 2016, 0)
+	if err != nil || level.Months != 11 || len(level.Interpolated) != 0 {
+		t.Errorf("Expected the average of 11 months with PartialYears, but got %+v, %v", level, err)
+	}
+	data.PartialYears = false
+
+	data.Gaps = GapLinear
+	jan, _ := data.YearInflation("US", 2016, 1)
+	mar, _ := data.YearInflation("US", 2016, 3)
+	level, err = data.IndexLevel("US", 2016, 2)
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if !floatsAlmostEqual(level.Value, (jan+mar)/2) || len(level.Interpolated) != 1 {
+		t.Errorf("Expected interpolated 2016-02, but got %+v", level)
+	}
+	level, err = data.IndexLevel("US", 2016, 0)
+	if err != nil || level.Months != 12 || len(level.Interpolated) != 1 || level.Interpolated[0] != (Period{2016, 2}) {
+		t.Errorf("Expected the 2016 average with 2016-02 interpolated, but got %+v, %v", level, err)
+	}
+
+	// The gap between 2016 and 2018 is filled too, but never beyond the last month
+	if _, err := data.IndexLevel("US", 2017, 0); err != nil {
+		t.Errorf("Did not expect error for the filled year 2017, but got: %v", err)
+	}
+	if _, err := data.IndexLevel("US", 2019, 1); err == nil {
+		t.Errorf("Expected error for a month after the last observation, but got none")
+	}
+}
+
+func TestParseGapPolicy(t *testing.T) {
+	for _, policy := range []GapPolicy{GapError, GapCarryForward, GapLinear, GapLogLinear} {
+		got, err := ParseGapPolicy(policy.String())
+		if err != nil || got != policy {
+			t.Errorf("Expected %v, but got %v, %v", policy, got, err)
+		}
+	}
+	if got, err := ParseGapPolicy(""); err != nil || got != GapError {
+		t.Errorf("Expected GapError for an empty policy, but got %v, %v", got, err)
+	}
+	if _, err := ParseGapPolicy("spline"); err == nil {
+		t.Errorf("Expected error for unknown policy, but got none")
+	}
+}
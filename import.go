@@ -0,0 +1,292 @@
+// inflation/import.go
+package inflation
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportedSeries is a series read from an external source, before it is merged into Data.
+type ImportedSeries struct {
+	Key        string            // Series key used by the source, e.g. ICP.M.DE.N.000000.4.INX
+	Area       string            // Country code used by the source
+	Name       string            // Country name, if the source provides one
+	Aliases    []string          // Additional names or codes for the country
+	BaseYear   int               // Index base year, 0 if unknown
+	SubIndex   string            // COICOP code of the category, empty for the headline index
+	Dimensions map[string]string // Source dimensions, e.g. FREQ -> M
+	Series     Series
+}
+
+// MergeReport describes the changes made by merging a series into a country.
+type MergeReport struct {
+	Country   string   `json:"country"`
+	Code      string   `json:"code"`
+	Series    string   `json:"series"` // COICOP code of the merged series, Headline for the headline index
+	Created   bool     `json:"created"`
+	Added     []Period `json:"added"`
+	Changed   []Period `json:"changed"`
+	Unchanged int      `json:"unchanged"`
+	// BaseMismatch is set if the imported values look like they are on a
+	// different index base than the existing ones, see CheckBase.
+	BaseMismatch *BaseCheck `json:"base_mismatch,omitempty"`
+	// Rescaled is the factor the imported values were multiplied by to match
+	// the existing base, see WithRescale; 0 if they were merged unchanged.
+	Rescaled float64 `json:"rescaled,omitempty"`
+}
+
+// BaseMismatchError is returned by MergeSeries for imported values that look
+// like they are on a different index base than the existing ones.
+type BaseMismatchError struct {
+	Country string    // Name of the country
+	Check   BaseCheck // Result of CheckBase
+	Rescale bool      // WithRescale was given, but there are no overlapping months
+}
+
+func (e *BaseMismatchError) Error() string {
+	if e.Rescale {
+		return fmt.Sprintf("cannot rescale values for '%s' without overlapping months: %s", e.Country, e.Check)
+	}
+	return fmt.Sprintf("values for '%s' may be on a different index base: %s", e.Country, e.Check)
+}
+
+// MergeOption configures how MergeSeries treats imported values that look
+// like they are on a different index base than the existing ones.
+type MergeOption func(*mergeOptions)
+
+type mergeOptions struct {
+	rescale bool
+	force   bool
+}
+
+// WithRescale rescales imported values on a different index base by the
+// ratio of the existing to the imported values over the months both cover,
+// see CheckBase. Values without overlapping months cannot be rescaled.
+func WithRescale() MergeOption {
+	return func(o *mergeOptions) {
+		o.rescale = true
+	}
+}
+
+// WithForce merges imported values on a different index base unchanged.
+// Combined with WithRescale, values are rescaled where they overlap.
+func WithForce() MergeOption {
+	return func(o *mergeOptions) {
+		o.force = true
+	}
+}
+
+// MergeAll merges every imported series, see MergeSeries. With dryRun the
+// reports describe the changes without modifying d.
+func (d *Data) MergeAll(imported []ImportedSeries, dryRun bool, opts ...MergeOption) ([]MergeReport, error) {
+	target := d
+	if dryRun {
+		clone := d.Clone()
+		target = &clone
+	}
+
+	reports := make([]MergeReport, 0, len(imported))
+	for _, in := range imported {
+		report, err := target.MergeSeries(in, opts...)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Clone returns a deep copy of the data.
+func (d *Data) Clone() Data {
+	return Data{Countries: cloneCountries(d.Countries), Gaps: d.Gaps, PartialYears: d.PartialYears}
+}
+
+// cloneCountries returns a deep copy of countries and their regions.
+func cloneCountries(countries []Country) []Country {
+	if countries == nil {
+		return nil
+	}
+	clone := make([]Country, len(countries))
+	for i, c := range countries {
+		c.Aliases = append([]string{}, c.Aliases...)
+		c.Splices = append([]SpliceInfo(nil), c.Splices...)
+		c.Inflation = copyInflation(c.Inflation)
+		if c.SubIndices != nil {
+			subIndices := make(map[string]SubIndex, len(c.SubIndices))
+			for code, sub := range c.SubIndices {
+				sub.Inflation = copyInflation(sub.Inflation)
+				subIndices[code] = sub
+			}
+			c.SubIndices = subIndices
+		}
+		c.Regions = cloneCountries(c.Regions)
+		clone[i] = c
+	}
+	return clone
+}
+
+func copyInflation(src map[string]map[string]float64) map[string]map[string]float64 {
+	inflation := make(map[string]map[string]float64, len(src))
+	for year, months := range src {
+		inflation[year] = make(map[string]float64, len(months))
+		for month, value := range months {
+			inflation[year][month] = value
+		}
+	}
+	return inflation
+}
+
+// MergeSeries merges the observations of an imported series into the country
+// matching its area, creating the country if it does not exist. Series of a
+// category (see ImportedSeries.SubIndex) are merged into the country's sub-index.
+// Imported values replace existing ones for the same period, and missing aliases are added.
+// Imported values that look like they are on a different index base than the
+// existing ones (see CheckBase) are refused unless WithRescale or WithForce is given.
+func (d *Data) MergeSeries(in ImportedSeries, opts ...MergeOption) (MergeReport, error) {
+	if in.Area == "" {
+		return MergeReport{}, fmt.Errorf("imported series '%s' has no reference area", in.Key)
+	}
+	var o mergeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	report := MergeReport{Added: []Period{}, Changed: []Period{}}
+	c, err := d.GetCountry(in.Area)
+	if err != nil {
+		c, err = d.addCountry(in.Area, in.Name, in.BaseYear)
+		if err != nil {
+			return report, err
+		}
+		report.Created = true
+	}
+	report.Country = c.Name
+	report.Code = c.Code
+
+	code := seriesCode(strings.TrimSpace(in.SubIndex))
+	report.Series = code
+	var existing Series
+	if code == Headline {
+		existing, err = c.Series()
+	} else {
+		sub := c.SubIndices[code]
+		existing, err = sub.Series()
+	}
+	if err != nil {
+		return report, err
+	}
+	report.BaseMismatch = CheckBase(existing, c.BaseYear, in)
+	if mismatch := report.BaseMismatch; mismatch != nil {
+		switch {
+		case o.rescale && mismatch.Overlap > 0:
+			report.Rescaled = 1 / mismatch.Ratio
+			in.Series = in.Series.Scale(report.Rescaled)
+		case !o.force:
+			return report, &BaseMismatchError{Country: c.Name, Check: *mismatch, Rescale: o.rescale}
+		}
+	}
+	c.addAliases(in.Aliases...)
+
+	merged := make(map[Period]float64, existing.Len()+in.Series.Len())
+	for _, o := range existing {
+		merged[o.Period] = o.Value
+	}
+	for _, o := range in.Series {
+		old, exists := merged[o.Period]
+		switch {
+		case !exists:
+			report.Added = append(report.Added, o.Period)
+		case math.Abs(old-o.Value) > 1e-9:
+			report.Changed = append(report.Changed, o.Period)
+		default:
+			report.Unchanged++
+		}
+		merged[o.Period] = o.Value
+	}
+
+	s := make(Series, 0, len(merged))
+	for p, v := range merged {
+		s = append(s, Observation{Period: p, Value: v})
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Period.Before(s[j].Period) })
+	if code == Headline {
+		c.SetSeries(s)
+	} else {
+		c.setSubIndex(code, s)
+	}
+	if c.BaseYear == 0 && report.Rescaled == 0 {
+		c.BaseYear = in.BaseYear
+	}
+
+	return report, nil
+}
+
+// addCountry adds an empty country for an area code. An area with a path such
+// as "US/0100" adds a region to the existing country (or region) it names.
+func (d *Data) addCountry(area, name string, baseYear int) (*Country, error) {
+	countries := &d.Countries
+	code := area
+	if i := strings.LastIndex(area, "/"); i >= 0 {
+		parent, err := d.GetCountry(area[:i])
+		if err != nil {
+			return nil, fmt.Errorf("cannot add region '%s': %v", area, err)
+		}
+		countries = &parent.Regions
+		code = area[i+1:]
+	}
+	if name == "" {
+		name = code
+	}
+	*countries = append(*countries, Country{
+		Name:      name,
+		Aliases:   []string{},
+		Code:      code,
+		BaseYear:  baseYear,
+		Inflation: make(map[string]map[string]float64),
+	})
+	return &(*countries)[len(*countries)-1], nil
+}
+
+// sortSeries orders observations by period. Later duplicates replace earlier ones.
+func sortSeries(s Series) Series {
+	sort.SliceStable(s, func(i, j int) bool { return s[i].Period.Before(s[j].Period) })
+	out := s[:0]
+	for _, o := range s {
+		if len(out) > 0 && out[len(out)-1].Period == o.Period {
+			out[len(out)-1] = o
+			continue
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+// baseYearPattern matches index base descriptions such as "2015=100" or "Index 2015 = 100".
+var baseYearPattern = regexp.MustCompile(`(\d{4})\s*=\s*100`)
+
+// parseBaseYear extracts the base year from an index base description, or returns 0.
+func parseBaseYear(s string) int {
+	m := baseYearPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+	year, _ := strconv.Atoi(m[1])
+	return year
+}
+
+// addAliases adds aliases the country cannot be found by yet.
+func (c *Country) addAliases(aliases ...string) {
+	for _, alias := range aliases {
+		known := strings.EqualFold(alias, c.Name) || strings.EqualFold(alias, c.Code)
+		for _, existing := range c.Aliases {
+			known = known || strings.EqualFold(alias, existing)
+		}
+		if !known && alias != "" {
+			c.Aliases = append(c.Aliases, alias)
+		}
+	}
+}
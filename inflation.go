@@ -3,159 +3,186 @@ package inflation
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
-// GetCountry retrieves a country by name, alias, or code.
+// GetCountry retrieves a country by name, alias, or code. Regions are
+// resolved with a path below their country, e.g. "US/Northeast".
 func (d *Data) GetCountry(query string) (*Country, error) {
-	query = strings.ToLower(query)
-	for i, country := range d.Countries {
+	if c := findCountry(d.Countries, query); c != nil {
+		return c, nil
+	}
+	path := strings.Split(query, "/")
+	c := findCountry(d.Countries, path[0])
+	if len(path) == 1 || c == nil {
+		return nil, fmt.Errorf("country '%s' not found", strings.ToLower(query))
+	}
+	for _, name := range path[1:] {
+		region := findCountry(c.Regions, name)
+		if region == nil {
+			return nil, fmt.Errorf("region '%s' not found for country '%s'", name, c.Name)
+		}
+		c = region
+	}
+	return c, nil
+}
+
+// findCountry returns the country matching a name, alias, or code, or nil.
+func findCountry(countries []Country, query string) *Country {
+	query = strings.ToLower(strings.TrimSpace(query))
+	for i, country := range countries {
 		if strings.ToLower(country.Name) == query || strings.ToLower(country.Code) == query {
-			return &d.Countries[i], nil // Return pointer to the actual country in the slice
+			return &countries[i] // Return pointer to the actual country in the slice
 		}
 		for _, alias := range country.Aliases {
 			if strings.ToLower(alias) == query {
-				return &d.Countries[i], nil
+				return &countries[i]
 			}
 		}
 	}
-	return nil, fmt.Errorf("country '%s' not found", query)
+	return nil
 }
 
-// GetAvailableYears returns a list of available years for a country.
+// GetAvailableYears returns a list of available years for a country in ascending order.
+// It returns nil if the country's data is malformed; see Country.Series for the error.
 func (c *Country) GetAvailableYears() []int {
-	years := make([]int, 0, len(c.Inflation))
-	for year := range c.Inflation {
-		y, _ := strconv.Atoi(year)
-		years = append(years, y)
+	s, err := c.Series()
+	if err != nil {
+		return nil
 	}
-	return years
+	return s.Years()
 }
 
-// GetFirstDate returns the earliest year and month for a country.
+// GetFirstDate returns the year and month of the earliest observation for a country.
+// It returns 0, 0 if the country has no (valid) data; use Coverage for the error.
 func (c *Country) GetFirstDate() (year int, month int) {
-	for yearStr, months := range c.Inflation {
-		y, _ := strconv.Atoi(yearStr)
-		if year == 0 || y < year {
-			year = y
-			for monthStr := range months {
-				m, _ := strconv.Atoi(monthStr)
-				if month == 0 || m < month {
-					month = m
-				}
-			}
-		}
+	s, err := c.Series()
+	if err != nil {
+		return 0, 0
+	}
+	first, ok := s.First()
+	if !ok {
+		return 0, 0
 	}
-	return year, month
+	return first.Period.Year, first.Period.Month
 }
 
-// GetLastDate returns the latest year and month for a country.
+// GetLastDate returns the year and month of the latest observation for a country.
+// It returns 0, 0 if the country has no (valid) data; use Coverage for the error.
 func (c *Country) GetLastDate() (year int, month int) {
-	for yearStr, months := range c.Inflation {
-		y, _ := strconv.Atoi(yearStr)
-		if y > year {
-			year = y
-			for monthStr := range months {
-				m, _ := strconv.Atoi(monthStr)
-				if m > month {
-					month = m
-				}
-			}
-		}
+	s, err := c.Series()
+	if err != nil {
+		return 0, 0
+	}
+	last, ok := s.Last()
+	if !ok {
+		return 0, 0
 	}
-	return year, month
+	return last.Period.Year, last.Period.Month
 }
 
-// YearInflation returns the inflation rate for a specific country and date.
-// If month is 0, it returns the average inflation rate for the year.
-// If month is between 1 and 12, it returns the rate for that specific month.
-func (d *Data) YearInflation(country string, year int, month int) (float64, error) {
-	c, err := d.GetCountry(country)
+// YearInflation returns the index level for a specific country and date.
+// If month is 0, it returns the average index level for the year.
+// If month is between 1 and 12, it returns the level for that specific month.
+// The level is not a rate; see YearOverYear and friends for percentage changes.
+// It uses the headline index unless another series is selected with WithSeries.
+func (d *Data) YearInflation(country string, year int, month int, opts ...QueryOption) (float64, error) {
+	level, err := d.IndexLevel(country, year, month, opts...)
 	if err != nil {
 		return 0, err
 	}
-	yearStr := fmt.Sprintf("%d", year)
-	yearData, exists := c.Inflation[yearStr]
-	if !exists {
-		return 0, fmt.Errorf("inflation data for year %d not found for country '%s'", year, country)
+	return level.Value, nil
+}
+
+// IndexLevel returns the index level like YearInflation, together with the
+// period and the number of months it is based on.
+func (d *Data) IndexLevel(country string, year int, month int, opts ...QueryOption) (IndexValue, error) {
+	_, s, err := d.countrySeries(country, opts...)
+	if err != nil {
+		return IndexValue{}, err
+	}
+	return s.indexValue(country, year, month)
+}
+
+// IndexValue is an index level used in a calculation, and how it was obtained.
+type IndexValue struct {
+	Period  Period  `json:"period"`        // Month is 0 for an annual average
+	Day     int     `json:"day,omitempty"` // Day of the month for a daily level, see DayIndexLevel
+	Value   float64 `json:"value"`         // Index level
+	Average bool    `json:"average"`       // Value is the average of the months available for the year
+	Months  int     `json:"months"`        // Number of months the value is based on
+	// Number of those months that are projected, see WithProjection
+	Projected int `json:"projected,omitempty"`
+	// Months of the value that were missing and filled, see Data.Gaps
+	Interpolated []Period `json:"interpolated,omitempty"`
+	// How a daily level was derived from the monthly levels
+	Method string `json:"method,omitempty"`
+	// Name of a quarter, half or fiscal year, e.g. 2024-Q3, whose first month is Period
+	Span string `json:"span,omitempty"`
+
+	through Period // Last month of Span
+}
+
+// indexValue returns the index level of a month, or the average level of the
+// year if month is 0. The country is only used in error messages.
+func (s Series) indexValue(country string, year, month int) (IndexValue, error) {
+	yearData := s.Year(year)
+	if yearData.Len() == 0 {
+		return IndexValue{}, fmt.Errorf("inflation data for year %d not found for country '%s'", year, country)
 	}
 	if month == 0 {
 		// Calculate average of all months
-		var sum float64
-		var count int
-		for _, rate := range yearData {
-			sum += rate
-			count++
-		}
-		if count == 0 {
-			return 0, fmt.Errorf("no monthly data available for year %d in country '%s'", year, country)
-		}
-		return sum / float64(count), nil
+		average, _ := yearData.Average()
+		return IndexValue{Period: Period{Year: year}, Value: average, Average: true, Months: yearData.Len()}, nil
 	} else if month >= 1 && month <= 12 {
-		monthStr := fmt.Sprintf("%02d", month) // Ensure monthStr is zero-padded
-		rate, exists := yearData[monthStr]
+		level, exists := yearData.Lookup(Period{Year: year, Month: month})
 		if !exists {
-			return 0, fmt.Errorf("inflation data for %d-%02d not found for country '%s'", year, month, country)
+			return IndexValue{}, fmt.Errorf("inflation data for %d-%02d not found for country '%s'", year, month, country)
 		}
-		return rate, nil
+		return IndexValue{Period: Period{Year: year, Month: month}, Value: level, Months: 1}, nil
 	} else {
-		return 0, fmt.Errorf("invalid month: %d", month)
+		return IndexValue{}, fmt.Errorf("invalid month: %d", month)
 	}
 }
 
-// CompareInflation calculates the equivalent price adjusted for inflation between two dates for a country.
-// Returns both the new price and the cumulative rate of inflation.
-func (d *Data) CompareInflation(country string, fromYear, fromMonth int, toYear, toMonth int, price float64) (float64, float64, error) {
-	fromRate, err := d.YearInflation(country, fromYear, fromMonth)
+// countrySeries retrieves a country and the index values of the selected
+// series as a sorted Series, with gaps filled according to d.Gaps and extended
+// by the selected projection.
+func (d *Data) countrySeries(country string, opts ...QueryOption) (*Country, indexSeries, error) {
+	c, err := d.GetCountry(country)
 	if err != nil {
-		return 0, 0, err
+		return nil, indexSeries{}, err
 	}
-	toRate, err := d.YearInflation(country, toYear, toMonth)
+	q := newQuery(opts)
+	s, err := c.SeriesFor(q.series)
 	if err != nil {
-		return 0, 0, err
+		return nil, indexSeries{}, err
 	}
-
-	// Calculate inflation factor
-	// Inflation Factor = toRate / fromRate
-	inflationFactor := toRate / fromRate
-	newPrice := price * inflationFactor
-
-	// Calculate cumulative rate of inflation
-	// Cumulative Rate (%) = (inflationFactor - 1) * 100
-	cumulativeRate := (inflationFactor - 1) * 100
-
-	return newPrice, cumulativeRate, nil
-}
-
-// CompareInflationWithBaseYear calculates the equivalent price adjusted for inflation relative to the BaseYear.
-func (d *Data) CompareInflationWithBaseYear(country string, targetYear, targetMonth int, price float64) (float64, error) {
-	c, err := d.GetCountry(country)
+	is, err := newIndexSeries(s, d.Gaps, q.projection)
 	if err != nil {
-		return 0, err
-	}
-
-	// Get the BaseYear
-	baseYear := c.BaseYear
-	if baseYear == 0 {
-		return 0, fmt.Errorf("base year not set for country '%s'", country)
+		return nil, indexSeries{}, err
 	}
+	is.partialYears = d.PartialYears
+	return c, is, nil
+}
 
-	// Get inflation rate for BaseYear (average of the year)
-	baseRate, err := d.YearInflation(country, baseYear, 0)
+// CompareInflation calculates the equivalent price adjusted for inflation between two dates for a country.
+// Returns both the new price and the cumulative rate of inflation; see Compare for the annualized rate.
+// It uses the headline index unless another series is selected with WithSeries.
+func (d *Data) CompareInflation(country string, fromYear, fromMonth int, toYear, toMonth int, price float64, opts ...QueryOption) (float64, float64, error) {
+	result, err := d.Compare(country, fromYear, fromMonth, toYear, toMonth, price, opts...)
 	if err != nil {
-		return 0, fmt.Errorf("error fetching BaseYear inflation rate: %v", err)
+		return 0, 0, err
 	}
+	return result.Price, result.Cumulative, nil
+}
 
-	// Get inflation rate for target date
-	targetRate, err := d.YearInflation(country, targetYear, targetMonth)
+// CompareInflationWithBaseYear calculates the equivalent price adjusted for inflation relative to the BaseYear.
+// See CompareWithBaseYear for the details of the calculation.
+func (d *Data) CompareInflationWithBaseYear(country string, targetYear, targetMonth int, price float64, opts ...QueryOption) (float64, error) {
+	result, err := d.CompareWithBaseYear(country, targetYear, targetMonth, price, opts...)
 	if err != nil {
-		return 0, fmt.Errorf("error fetching target inflation rate: %v", err)
+		return 0, err
 	}
-
-	// Calculate inflation factor relative to BaseYear
-	inflationFactor := targetRate / baseRate
-	newPrice := price * inflationFactor
-
-	return newPrice, nil
+	return result.Price, nil
 }
@@ -748,6 +748,10 @@ func TestGetFirstDate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			country, err := data.GetCountry(tt.countryName)
+			if err == nil {
+				// A country without data has no first or last date
+				_, err = country.Coverage()
+			}
 			if tt.expectError {
 				if err == nil {
 					t.Errorf("Expected error for country '%s', but got none", tt.countryName)
@@ -786,10 +790,10 @@ func TestGetLastDate(t *testing.T) {
 			expectError:   false,
 		},
 		{
-			name:          "Country with single year and month",
+			name:          "Country with two years",
 			countryName:   "Germany",
-			expectedYear:  2015,
-			expectedMonth: 6,
+			expectedYear:  2018,
+			expectedMonth: 12,
 			expectError:   false,
 		},
 		{
@@ -820,6 +824,10 @@ func TestGetLastDate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			country, err := data.GetCountry(tt.countryName)
+			if err == nil {
+				// A country without data has no first or last date
+				_, err = country.Coverage()
+			}
 			if tt.expectError {
 				if err == nil {
 					t.Errorf("Expected error for country '%s', but got none", tt.countryName)
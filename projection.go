@@ -0,0 +1,258 @@
+// inflation/projection.go
+package inflation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ProjectionYears is how far beyond the last observation a projection extends
+// a series, counted in calendar years after the year of the last observation.
+const ProjectionYears = 50
+
+// Projection extends an index series beyond its last observation, see WithProjection.
+type Projection interface {
+	// Project returns the index levels of the n months following the last
+	// observation of s.
+	Project(s Series, n int) (Series, error)
+	// String describes the model, e.g. "constant 2.1% a year (last 12 months)".
+	String() string
+}
+
+// ConstantRate projects the average monthly growth of the last Months months
+// (12 if zero), i.e. the latest year-over-year rate for the default.
+type ConstantRate struct {
+	Months int
+}
+
+func (m ConstantRate) months() int {
+	if m.Months <= 0 {
+		return 12
+	}
+	return m.Months
+}
+
+// Project implements Projection.
+func (m ConstantRate) Project(s Series, n int) (Series, error) {
+	last, ok := s.Last()
+	if !ok {
+		return nil, fmt.Errorf("no data to project")
+	}
+	start := last.Period.AddMonths(-m.months())
+	level, ok := s.Lookup(start)
+	if !ok || level <= 0 || last.Value <= 0 {
+		return nil, fmt.Errorf("constant rate projection needs %s and %s", start, last.Period)
+	}
+	growth := math.Pow(last.Value/level, 1/float64(m.months()))
+	return projectGrowth(last, growth, n), nil
+}
+
+func (m ConstantRate) String() string {
+	return fmt.Sprintf("constant rate of the last %d months", m.months())
+}
+
+// LogTrend fits a straight line to the logarithm of the index over the last
+// Months months (120 if zero) by least squares and continues the last
+// observation with the slope of that line, so the projection has no jump.
+type LogTrend struct {
+	Months int
+}
+
+func (m LogTrend) months() int {
+	if m.Months <= 0 {
+		return 120
+	}
+	return m.Months
+}
+
+// Project implements Projection.
+func (m LogTrend) Project(s Series, n int) (Series, error) {
+	last, ok := s.Last()
+	if !ok {
+		return nil, fmt.Errorf("no data to project")
+	}
+	window := s.Range(last.Period.AddMonths(1-m.months()), last.Period)
+	if window.Len() < 2 {
+		return nil, fmt.Errorf("trend projection needs at least 2 months of data")
+	}
+
+	// Least squares slope of log(level) over the month number
+	var sumX, sumY, sumXX, sumXY float64
+	for _, o := range window {
+		if o.Value <= 0 {
+			return nil, fmt.Errorf("trend projection needs positive index levels, %s is %g", o.Period, o.Value)
+		}
+		x := float64(last.Period.MonthsUntil(o.Period))
+		y := math.Log(o.Value)
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+	}
+	count := float64(window.Len())
+	slope := (count*sumXY - sumX*sumY) / (count*sumXX - sumX*sumX)
+	return projectGrowth(last, math.Exp(slope), n), nil
+}
+
+func (m LogTrend) String() string {
+	return fmt.Sprintf("log-linear trend of the last %d months", m.months())
+}
+
+// TargetRate projects a fixed annual inflation rate in percent, e.g. 2 for a
+// central bank target.
+type TargetRate struct {
+	Rate float64
+}
+
+// Project implements Projection.
+func (m TargetRate) Project(s Series, n int) (Series, error) {
+	last, ok := s.Last()
+	if !ok {
+		return nil, fmt.Errorf("no data to project")
+	}
+	if m.Rate <= -100 {
+		return nil, fmt.Errorf("invalid target rate %g%%", m.Rate)
+	}
+	return projectGrowth(last, math.Pow(1+m.Rate/100, 1.0/12), n), nil
+}
+
+func (m TargetRate) String() string {
+	return fmt.Sprintf("target rate of %s%% a year", strconv.FormatFloat(m.Rate, 'f', -1, 64))
+}
+
+// projectGrowth returns n months after last, each growing by a monthly factor.
+func projectGrowth(last Observation, growth float64, n int) Series {
+	projected := make(Series, n)
+	value := last.Value
+	for i := range projected {
+		value *= growth
+		projected[i] = Observation{Period: last.Period.AddMonths(i + 1), Value: value}
+	}
+	return projected
+}
+
+// ParseProjection parses a projection model: "constant" or "constant:MONTHS",
+// "trend" or "trend:MONTHS", and "target:RATE" with RATE in percent a year.
+// An empty string returns nil, i.e. no projection.
+func ParseProjection(s string) (Projection, error) {
+	if s == "" {
+		return nil, nil
+	}
+	name, arg, hasArg := strings.Cut(strings.ToLower(strings.TrimSpace(s)), ":")
+	switch name {
+	case "constant", "trend":
+		months := 0
+		if hasArg {
+			var err error
+			months, err = strconv.Atoi(arg)
+			if err != nil || months < 1 {
+				return nil, fmt.Errorf("invalid number of months '%s' in projection '%s'", arg, s)
+			}
+		}
+		if name == "constant" {
+			return ConstantRate{Months: months}, nil
+		}
+		if months == 1 {
+			return nil, fmt.Errorf("trend projection needs at least 2 months")
+		}
+		return LogTrend{Months: months}, nil
+	case "target":
+		rate, err := strconv.ParseFloat(strings.TrimSuffix(arg, "%"), 64)
+		if !hasArg || err != nil {
+			return nil, fmt.Errorf("projection '%s' needs an annual rate in percent, e.g. target:2", s)
+		}
+		return TargetRate{Rate: rate}, nil
+	}
+	return nil, fmt.Errorf("unknown projection '%s' (use constant, trend or target:RATE)", s)
+}
+
+// indexSeries is the series a query reads index levels from, with its gaps
+// filled and extended by the projection of the query if one was selected.
+type indexSeries struct {
+	Series
+	observed     Period          // Last observed month; later months are projected
+	projection   Projection      // Nil if the series is not projected
+	filled       map[Period]bool // Months filled by the gap policy
+	partialYears bool            // Annual averages may use incomplete years
+}
+
+// newIndexSeries fills the gaps of s according to gaps and extends it through
+// December ProjectionYears after its last observation if p is not nil.
+func newIndexSeries(s Series, gaps GapPolicy, p Projection) (indexSeries, error) {
+	is := indexSeries{Series: s}
+	last, ok := s.Last()
+	if !ok {
+		return is, nil
+	}
+	is.observed = last.Period
+
+	s, filled, err := FillGaps(s, gaps)
+	if err != nil {
+		return is, err
+	}
+	is.Series = s
+	if len(filled) > 0 {
+		is.filled = make(map[Period]bool, len(filled))
+		for _, p := range filled {
+			is.filled[p] = true
+		}
+	}
+
+	if p == nil {
+		return is, nil
+	}
+	end := Period{Year: last.Period.Year + ProjectionYears, Month: 12}
+	projected, err := p.Project(s, last.Period.MonthsUntil(end))
+	if err != nil {
+		return is, fmt.Errorf("projection: %v", err)
+	}
+	is.Series = append(append(Series{}, s...), projected...)
+	is.projection = p
+	return is, nil
+}
+
+// indexValue returns the index level like Series.indexValue, refuses the
+// average of an incomplete year unless partialYears is set, and reports the
+// filled and projected months the level is based on.
+func (s indexSeries) indexValue(country string, year, month int) (IndexValue, error) {
+	v, err := s.Series.indexValue(country, year, month)
+	if err != nil {
+		return v, err
+	}
+	var months Series
+	if month == 0 {
+		if v.Months < 12 && !s.partialYears {
+			return IndexValue{}, &IncompleteError{Country: country, Span: YearSpan(year), Months: v.Months}
+		}
+		months = s.Year(year)
+	} else {
+		months = Series{{Period: v.Period, Value: v.Value}}
+	}
+	s.annotate(&v, months)
+	return v, nil
+}
+
+// annotate reports the filled and projected months among those of a level.
+func (s indexSeries) annotate(v *IndexValue, months Series) {
+	for _, o := range months {
+		if s.filled[o.Period] {
+			v.Interpolated = append(v.Interpolated, o.Period)
+		}
+		if s.projection != nil && s.observed.Before(o.Period) {
+			v.Projected++
+		}
+	}
+}
+
+// projectionName returns the description of the projection if any of the
+// values is projected, and "" otherwise.
+func (s indexSeries) projectionName(values ...IndexValue) string {
+	for _, v := range values {
+		if v.Projected > 0 {
+			return s.projection.String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+// projection_test.go
+package inflation
+
+import (
+	"math"
+	"testing"
+)
+
+// growthSeries returns 24 months from 2020-01 growing by a monthly factor.
+func growthSeries(growth float64) Series {
+	s := make(Series, 24)
+	value := 100.0
+	for i := range s {
+		s[i] = Observation{Period: Period{Year: 2020, Month: 1}.AddMonths(i), Value: value}
+		value *= growth
+	}
+	return s
+}
+
+func TestProjections(t *testing.T) {
+	growth := math.Pow(1.03, 1.0/12)
+	s := growthSeries(growth)
+	last, _ := s.Last()
+
+	tests := []struct {
+		name  string
+		model Projection
+	}{
+		{"constant rate", ConstantRate{}},
+		{"log trend", LogTrend{Months: 24}},
+		{"target rate", TargetRate{Rate: 3}},
+	}
+	for _, tt := range tests {
+		projected, err := tt.model.Project(s, 12)
+		if err != nil {
+			t.Errorf("%s: Did not expect error, but got: %v", tt.name, err)
+			continue
+		}
+		if projected.Len() != 12 || projected[0].Period != (Period{2022, 1}) {
+			t.Errorf("%s: Expected 12 months from 2022-01, but got %v", tt.name, projected)
+			continue
+		}
+		// The series grows 3% a year, which every model should continue
+		if !floatsAlmostEqual(projected[11].Value, last.Value*1.03) {
+			t.Errorf("%s: Expected %.4f for 2022-12, but got %.4f", tt.name, last.Value*1.03, projected[11].Value)
+		}
+	}
+
+	if _, err := (ConstantRate{Months: 36}).Project(s, 12); err == nil {
+		t.Errorf("Expected error for a constant rate without enough history, but got none")
+	}
+	if _, err := (LogTrend{}).Project(s[:1], 12); err == nil {
+		t.Errorf("Expected error for a trend of a single month, but got none")
+	}
+}
+
+func TestWithProjection(t *testing.T) {
+	data := createTestData()
+
+	if _, err := data.IndexLevel("US", 2020, 0); err == nil {
+		t.Errorf("Expected error for a year after the last observation without projection, but got none")
+	}
+
+	level, err := data.IndexLevel("US", 2019, 6, WithProjection(TargetRate{Rate: 2}))
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	dec, _ := data.YearInflation("US", 2018, 12)
+	if level.Projected != 1 || !floatsAlmostEqual(level.Value, dec*math.Pow(1.02, 6.0/12)) {
+		t.Errorf("Expected projected 2019-06 level, but got %+v", level)
+	}
+
+	observed, err := data.IndexLevel("US", 2018, 0, WithProjection(TargetRate{Rate: 2}))
+	if err != nil || observed.Projected != 0 {
+		t.Errorf("Expected observed 2018 average, but got %+v, %v", observed, err)
+	}
+
+	result, err := data.Compare("US", 2018, 0, 2020, 0, 100, WithProjection(TargetRate{Rate: 2}))
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if result.To.Projected != 12 || result.From.Projected != 0 || result.Projection == "" {
+		t.Errorf("Expected a comparison to a projected year, but got %+v", result)
+	}
+
+	result, err = data.Compare("US", 2015, 0, 2018, 0, 100, WithProjection(TargetRate{Rate: 2}))
+	if err != nil || result.Projection != "" {
+		t.Errorf("Expected an observed comparison without projection, but got %+v, %v", result, err)
+	}
+}
+
+func TestParseProjection(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Projection
+		wantErr  bool
+	}{
+		{"", nil, false},
+		{"constant", ConstantRate{}, false},
+		{"Constant:24", ConstantRate{Months: 24}, false},
+		{"trend", LogTrend{}, false},
+		{"trend:60", LogTrend{Months: 60}, false},
+		{"target:2", TargetRate{Rate: 2}, false},
+		{"target:2.5%", TargetRate{Rate: 2.5}, false},
+		{"target", nil, true},
+		{"trend:1", nil, true},
+		{"constant:x", nil, true},
+		{"arima", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseProjection(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for '%s', but got none", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Did not expect error for '%s', but got: %v", tt.input, err)
+		} else if got != tt.expected {
+			t.Errorf("Expected %v for '%s', but got %v", tt.expected, tt.input, got)
+		}
+	}
+}
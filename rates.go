@@ -0,0 +1,104 @@
+// inflation/rates.go
+package inflation
+
+import (
+	"fmt"
+)
+
+// The values stored in Country.Inflation are index levels (e.g. HICP with
+// BaseYear = 100), not rates. The functions below derive percentage changes
+// from those levels. Like YearInflation, they use the headline index unless
+// another series is selected with WithSeries.
+
+// YearOverYear returns the percentage change of the index between the given
+// month and the same month of the previous year.
+func (d *Data) YearOverYear(country string, year, month int, opts ...QueryOption) (float64, error) {
+	if month < 1 || month > 12 {
+		return 0, fmt.Errorf("invalid month: %d", month)
+	}
+	from, err := d.YearInflation(country, year-1, month, opts...)
+	if err != nil {
+		return 0, err
+	}
+	to, err := d.YearInflation(country, year, month, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return percentChange(from, to)
+}
+
+// MonthOverMonth returns the percentage change of the index between the given
+// month and the month before it.
+func (d *Data) MonthOverMonth(country string, year, month int, opts ...QueryOption) (float64, error) {
+	if month < 1 || month > 12 {
+		return 0, fmt.Errorf("invalid month: %d", month)
+	}
+	prevYear, prevMonth := year, month-1
+	if prevMonth == 0 {
+		prevYear, prevMonth = year-1, 12
+	}
+	from, err := d.YearInflation(country, prevYear, prevMonth, opts...)
+	if err != nil {
+		return 0, err
+	}
+	to, err := d.YearInflation(country, year, month, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return percentChange(from, to)
+}
+
+// AnnualAverageRate returns the percentage change between the annual average
+// index of the given year and the annual average index of the previous year.
+// This is the headline "annual inflation rate" most statistics offices publish.
+func (d *Data) AnnualAverageRate(country string, year int, opts ...QueryOption) (float64, error) {
+	from, err := d.YearInflation(country, year-1, 0, opts...)
+	if err != nil {
+		return 0, err
+	}
+	to, err := d.YearInflation(country, year, 0, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return percentChange(from, to)
+}
+
+// DecemberOverDecember returns the percentage change of the index between
+// December of the given year and December of the previous year.
+func (d *Data) DecemberOverDecember(country string, year int, opts ...QueryOption) (float64, error) {
+	return d.YearOverYear(country, year, 12, opts...)
+}
+
+// SpanYearOverYear returns the percentage change between the average index of
+// a span and that of the same span a year earlier, e.g. 2024-Q3 over 2023-Q3.
+func (d *Data) SpanYearOverYear(country string, span Span, opts ...QueryOption) (float64, error) {
+	return d.spanChange(country, span, 12, opts...)
+}
+
+// SpanOverSpan returns the percentage change between the average index of a
+// span and that of the span of the same length before it, e.g. 2024-Q3 over
+// 2024-Q2.
+func (d *Data) SpanOverSpan(country string, span Span, opts ...QueryOption) (float64, error) {
+	return d.spanChange(country, span, span.Months(), opts...)
+}
+
+// spanChange returns the percentage change from the span lag months earlier.
+func (d *Data) spanChange(country string, span Span, lag int, opts ...QueryOption) (float64, error) {
+	from, err := d.SpanIndexLevel(country, span.AddMonths(-lag), opts...)
+	if err != nil {
+		return 0, err
+	}
+	to, err := d.SpanIndexLevel(country, span, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return percentChange(from.Value, to.Value)
+}
+
+// percentChange returns the change from one index level to another in percent.
+func percentChange(from, to float64) (float64, error) {
+	if from == 0 {
+		return 0, fmt.Errorf("cannot compute rate from a zero index level")
+	}
+	return (to/from - 1) * 100, nil
+}
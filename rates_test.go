@@ -0,0 +1,140 @@
+// rates_test.go
+package inflation
+
+import (
+	"testing"
+)
+
+func TestYearOverYear(t *testing.T) {
+	data := createTestData()
+
+	tests := []struct {
+		country     string
+		year        int
+		month       int
+		expected    float64
+		expectError bool
+	}{
+		// 2016-03 (0.35) against 2015-03 (0.3)
+		{"US", 2016, 3, (0.35/0.3 - 1) * 100, false},
+		// 2016-01 (0.15) against 2015-01 (0.1)
+		{"US", 2016, 1, 50, false},
+		// No data for 2017
+		{"US", 2018, 6, 0.0, true},
+		// Annual averages are not accepted
+		{"US", 2016, 0, 0.0, true},
+		// Non-existent country
+		{"France", 2016, 3, 0.0, true},
+	}
+
+	for _, tt := range tests {
+		rate, err := data.YearOverYear(tt.country, tt.year, tt.month)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("Expected error for YearOverYear with country='%s', year=%d, month=%d, but got none", tt.country, tt.year, tt.month)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("Did not expect error for YearOverYear with country='%s', year=%d, month=%d, but got: %v", tt.country, tt.year, tt.month, err)
+			} else if !floatsAlmostEqual(rate, tt.expected) {
+				t.Errorf("For YearOverYear with country='%s', year=%d, month=%d, expected rate=%.6f, got=%.6f", tt.country, tt.year, tt.month, tt.expected, rate)
+			}
+		}
+	}
+}
+
+func TestMonthOverMonth(t *testing.T) {
+	data := createTestData()
+
+	tests := []struct {
+		country     string
+		year        int
+		month       int
+		expected    float64
+		expectError bool
+	}{
+		// 2015-02 (0.2) against 2015-01 (0.1)
+		{"US", 2015, 2, 100, false},
+		// 2016-01 (0.15) against 2015-12 (0.3) crosses the year boundary
+		{"US", 2016, 1, -50, false},
+		// No data for 2014-12
+		{"US", 2015, 1, 0.0, true},
+		// Invalid month
+		{"US", 2015, 13, 0.0, true},
+	}
+
+	for _, tt := range tests {
+		rate, err := data.MonthOverMonth(tt.country, tt.year, tt.month)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("Expected error for MonthOverMonth with country='%s', year=%d, month=%d, but got none", tt.country, tt.year, tt.month)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("Did not expect error for MonthOverMonth with country='%s', year=%d, month=%d, but got: %v", tt.country, tt.year, tt.month, err)
+			} else if !floatsAlmostEqual(rate, tt.expected) {
+				t.Errorf("For MonthOverMonth with country='%s', year=%d, month=%d, expected rate=%.6f, got=%.6f", tt.country, tt.year, tt.month, tt.expected, rate)
+			}
+		}
+	}
+}
+
+func TestAnnualRates(t *testing.T) {
+	data := createTestData()
+
+	tests := []struct {
+		name             string
+		country          string
+		year             int
+		expectedAverage  float64
+		expectedDecember float64
+		expectError      bool
+	}{
+		{
+			name:             "US 2016 against 2015",
+			country:          "US",
+			year:             2016,
+			expectedAverage:  (0.25/0.2 - 1) * 100, // 25%
+			expectedDecember: (0.35/0.3 - 1) * 100, // ≈16.67%
+			expectError:      false,
+		},
+		{
+			name:        "US 2015 without previous year",
+			country:     "US",
+			year:        2015,
+			expectError: true,
+		},
+		{
+			name:        "Germany 2018 without previous year",
+			country:     "Germany",
+			year:        2018,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			average, err := data.AnnualAverageRate(tt.country, tt.year)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for AnnualAverageRate with country='%s', year=%d, but got none", tt.country, tt.year)
+				}
+			} else if err != nil {
+				t.Errorf("Did not expect error for AnnualAverageRate with country='%s', year=%d, but got: %v", tt.country, tt.year, err)
+			} else if !floatsAlmostEqual(average, tt.expectedAverage) {
+				t.Errorf("For AnnualAverageRate with country='%s', year=%d, expected rate=%.6f, got=%.6f", tt.country, tt.year, tt.expectedAverage, average)
+			}
+
+			december, err := data.DecemberOverDecember(tt.country, tt.year)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for DecemberOverDecember with country='%s', year=%d, but got none", tt.country, tt.year)
+				}
+			} else if err != nil {
+				t.Errorf("Did not expect error for DecemberOverDecember with country='%s', year=%d, but got: %v", tt.country, tt.year, err)
+			} else if !floatsAlmostEqual(december, tt.expectedDecember) {
+				t.Errorf("For DecemberOverDecember with country='%s', year=%d, expected rate=%.6f, got=%.6f", tt.country, tt.year, tt.expectedDecember, december)
+			}
+		})
+	}
+}
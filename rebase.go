@@ -0,0 +1,126 @@
+// inflation/rebase.go
+package inflation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Rebase returns the series rescaled so that the given year (average of its
+// months, if month is 0) or month equals 100.
+func (s Series) Rebase(year, month int) (Series, error) {
+	if month < 0 || month > 12 {
+		return nil, fmt.Errorf("invalid month: %d", month)
+	}
+	return s.RebaseSpan(spanOf(year, month))
+}
+
+// RebaseSpan returns the series rescaled so that the average of the months
+// of a span equals 100. All months of the span must be present.
+func (s Series) RebaseSpan(span Span) (Series, error) {
+	months := s.Range(span.From, span.To)
+	if span.Months() == 1 && months.Len() == 0 {
+		return nil, fmt.Errorf("no value for base period %s", span)
+	}
+	if months.Len() != span.Months() {
+		return nil, fmt.Errorf("base period %s has %d of %d months", span, months.Len(), span.Months())
+	}
+	base, _ := months.Average()
+	if base == 0 {
+		return nil, fmt.Errorf("cannot rebase to a zero index level")
+	}
+	return s.Scale(100 / base), nil
+}
+
+// Rebase rescales the whole series of a country, including its sub-indices,
+// so that the given year (average, if month is 0) or month equals 100, and sets
+// BaseYear to year. Rates and price comparisons are not affected.
+func (d *Data) Rebase(country string, year, month int) error {
+	if month < 0 || month > 12 {
+		return fmt.Errorf("invalid month: %d", month)
+	}
+	return d.RebaseSpan(country, spanOf(year, month))
+}
+
+// RebaseSpan rescales the series of a country like Rebase so that the average
+// of a span equals 100, and sets BaseYear to the year the span ends in.
+func (d *Data) RebaseSpan(country string, span Span) error {
+	c, err := d.GetCountry(country)
+	if err != nil {
+		return err
+	}
+	rebased := make(map[string]Series)
+	for _, code := range c.SeriesCodes() {
+		s, err := c.SeriesFor(code)
+		if err != nil {
+			return err
+		}
+		rebased[code], err = s.RebaseSpan(span)
+		if err != nil {
+			return fmt.Errorf("cannot rebase '%s' series %s: %v", c.Name, code, err)
+		}
+	}
+	for code, s := range rebased {
+		if code == Headline {
+			c.SetSeries(s)
+		} else {
+			c.setSubIndex(code, s)
+		}
+	}
+	c.BaseYear = span.To.Year
+	return nil
+}
+
+// BaseCheck describes why imported values look like they are on a different
+// index base than the existing series.
+type BaseCheck struct {
+	Overlap          int     `json:"overlap"`            // Periods present in both series
+	Ratio            float64 `json:"ratio"`              // Median of imported / existing over the overlap
+	BaseYear         int     `json:"base_year"`          // Base year of the existing series
+	ImportedBaseYear int     `json:"imported_base_year"` // Declared base year of the imported series, 0 if unknown
+}
+
+func (b BaseCheck) String() string {
+	if b.Overlap > 0 {
+		return fmt.Sprintf("imported values are %.4f times the existing values over %d overlapping months", b.Ratio, b.Overlap)
+	}
+	return fmt.Sprintf("imported values have base year %d, existing values have base year %d", b.ImportedBaseYear, b.BaseYear)
+}
+
+// baseTolerance is the relative difference between overlapping values above
+// which they are considered to be on different bases. Revisions of the same
+// series are usually well below it.
+const baseTolerance = 0.02
+
+// CheckBase reports whether imported values look like they are on a different
+// index base than the existing values of the country: their overlapping
+// values differ by a consistent factor, or without overlap, their declared
+// base years differ. It returns nil if no mismatch is detected.
+func CheckBase(existing Series, existingBaseYear int, in ImportedSeries) *BaseCheck {
+	var ratios []float64
+	for _, o := range in.Series {
+		value, ok := existing.Lookup(o.Period)
+		if ok && value != 0 {
+			ratios = append(ratios, o.Value/value)
+		}
+	}
+
+	check := &BaseCheck{Overlap: len(ratios), BaseYear: existingBaseYear, ImportedBaseYear: in.BaseYear}
+	if len(ratios) == 0 {
+		if in.BaseYear != 0 && existingBaseYear != 0 && in.BaseYear != existingBaseYear && existing.Len() > 0 {
+			return check
+		}
+		return nil
+	}
+
+	sort.Float64s(ratios)
+	check.Ratio = ratios[len(ratios)/2]
+	if len(ratios)%2 == 0 {
+		check.Ratio = (ratios[len(ratios)/2-1] + ratios[len(ratios)/2]) / 2
+	}
+	if math.Abs(check.Ratio-1) <= baseTolerance {
+		return nil
+	}
+	return check
+}
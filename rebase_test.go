@@ -0,0 +1,139 @@
+// rebase_test.go
+package inflation
+
+import (
+	"testing"
+)
+
+func TestRebase(t *testing.T) {
+	tests := []struct {
+		name        string
+		year        int
+		month       int
+		period      Period  // A period to check after rebasing
+		expected    float64 // Its expected value
+		expectError bool
+	}{
+		{"Annual average", 2016, 0, Period{2015, 1}, 0.1 / 0.25 * 100, false},
+		{"Single month", 2015, 3, Period{2015, 3}, 100, false},
+		{"Missing year", 2017, 0, Period{}, 0, true},
+		{"Missing month", 2017, 6, Period{}, 0, true},
+		{"Invalid month", 2016, 13, Period{}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := createTestData()
+			yoyBefore, _ := data.YearOverYear("US", 2016, 5)
+
+			err := data.Rebase("US", tt.year, tt.month)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error rebasing to %d-%02d, but got none", tt.year, tt.month)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Did not expect error rebasing to %d-%02d, but got: %v", tt.year, tt.month, err)
+			}
+
+			level, err := data.YearInflation("US", tt.period.Year, tt.period.Month)
+			if err != nil || !floatsAlmostEqual(level, tt.expected) {
+				t.Errorf("Expected %.6f for %s after rebasing, got %.6f (err=%v)", tt.expected, tt.period, level, err)
+			}
+			average, _ := data.YearInflation("US", 2016, 0)
+			if tt.month == 0 && !floatsAlmostEqual(average, 100) {
+				t.Errorf("Expected the 2016 average to be 100 after rebasing, got %.6f", average)
+			}
+
+			country, _ := data.GetCountry("US")
+			if country.BaseYear != tt.year {
+				t.Errorf("Expected base year %d, got %d", tt.year, country.BaseYear)
+			}
+
+			yoyAfter, _ := data.YearOverYear("US", 2016, 5)
+			if !floatsAlmostEqual(yoyBefore, yoyAfter) {
+				t.Errorf("Expected rebasing to keep rates, got %.6f before and %.6f after", yoyBefore, yoyAfter)
+			}
+		})
+	}
+}
+
+func TestCheckBase(t *testing.T) {
+	existing := defaultSeries(t, "GR")
+	recent := existing.Range(Period{2023, 1}, Period{2024, 12})
+
+	tests := []struct {
+		name           string
+		in             ImportedSeries
+		expectMismatch bool
+	}{
+		{"Same base", ImportedSeries{Series: recent, BaseYear: 2015}, false},
+		{"Small revisions", ImportedSeries{Series: recent.Scale(1.001), BaseYear: 2015}, false},
+		{"Rebased to 2025", ImportedSeries{Series: recent.Scale(0.82), BaseYear: 2025}, true},
+		{"Undeclared different base", ImportedSeries{Series: recent.Scale(1.35)}, true},
+		{"No overlap, different declared base", ImportedSeries{Series: Series{{Period{2025, 1}, 100.4}}, BaseYear: 2025}, true},
+		{"No overlap, unknown base", ImportedSeries{Series: Series{{Period{2025, 1}, 100.4}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := CheckBase(existing, 2015, tt.in)
+			if tt.expectMismatch && check == nil {
+				t.Errorf("Expected a base mismatch, but got none")
+			}
+			if !tt.expectMismatch && check != nil {
+				t.Errorf("Did not expect a base mismatch, but got: %s", check)
+			}
+		})
+	}
+
+	data, _ := Default()
+	rebased := ImportedSeries{Area: "GR", Series: recent.Scale(0.82), BaseYear: 2025}
+	report, err := data.MergeSeries(rebased)
+	if err == nil {
+		t.Errorf("Expected error for a base mismatch, but got none")
+	}
+	if report.BaseMismatch == nil || report.BaseMismatch.Overlap != 24 || !floatsAlmostEqual(report.BaseMismatch.Ratio, 0.82) {
+		t.Errorf("Expected a base mismatch with ratio 0.82 over 24 months, got %+v", report.BaseMismatch)
+	}
+	if v, _ := data.YearInflation("GR", 2024, 12); !floatsAlmostEqual(v, recent[23].Value) {
+		t.Errorf("Expected a refused merge to leave the series unchanged, got %.4f for 2024-12", v)
+	}
+
+	// Rescaling restores the existing values
+	clone := data.Clone()
+	report, err = clone.MergeSeries(rebased, WithRescale())
+	if err != nil {
+		t.Fatalf("Failed to merge series with rescaling: %v", err)
+	}
+	if !floatsAlmostEqual(report.Rescaled, 1/0.82) || len(report.Changed) != 0 {
+		t.Errorf("Expected the values to be rescaled to the existing ones, got %+v", report)
+	}
+	if _, err := clone.MergeSeries(ImportedSeries{Area: "GR", Series: Series{{Period{2030, 1}, 100}}, BaseYear: 2025}, WithRescale()); err == nil {
+		t.Errorf("Expected error for rescaling without overlapping months, but got none")
+	}
+
+	report, err = data.MergeSeries(rebased, WithForce())
+	if err != nil || report.Rescaled != 0 || len(report.Changed) != 24 {
+		t.Errorf("Expected the values to be merged unchanged with WithForce, got %+v, %v", report, err)
+	}
+}
+
+func TestRebaseSpan(t *testing.T) {
+	data := createTestData()
+	// 2015-Q1 of the US is 0.1, 0.2 and 0.3
+	q1, _ := QuarterSpan(2015, 1)
+	if err := data.RebaseSpan("US", q1); err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	level, _ := data.SpanIndexLevel("US", q1)
+	if !floatsAlmostEqual(level.Value, 100) {
+		t.Errorf("Expected 2015-Q1 to average 100 after rebasing, got %.6f", level.Value)
+	}
+
+	fy, _ := FiscalYearSpan(2017, 7)
+	if err := data.RebaseSpan("US", fy); err == nil {
+		t.Errorf("Expected error rebasing to the incomplete %s, but got none", fy)
+	}
+}
@@ -0,0 +1,133 @@
+// region_test.go
+package inflation
+
+import (
+	"testing"
+)
+
+// createRegionTestData adds a region with a metro area to the US test data.
+func createRegionTestData() Data {
+	data := createTestData()
+	data.Countries[0].Regions = []Country{
+		{
+			Name:     "Northeast",
+			Aliases:  []string{"NE"},
+			Code:     "0100",
+			BaseYear: 2015,
+			Inflation: map[string]map[string]float64{
+				"2015": {"01": 100},
+				"2016": {"01": 103},
+			},
+			Regions: []Country{
+				{
+					Name: "Boston-Cambridge-Newton",
+					Code: "S11A",
+					Inflation: map[string]map[string]float64{
+						"2016": {"01": 250},
+					},
+				},
+			},
+		},
+	}
+	return data
+}
+
+func TestGetCountryRegion(t *testing.T) {
+	data := createRegionTestData()
+
+	tests := []struct {
+		query       string
+		expected    string
+		expectError bool
+	}{
+		{"US/Northeast", "Northeast", false},
+		{"united states/ne", "Northeast", false},
+		{"USA/0100", "Northeast", false},
+		{"US/Northeast/S11A", "Boston-Cambridge-Newton", false},
+		{"US", "United States", false},
+		{"US/West", "", true},
+		{"XX/Northeast", "", true},
+		{"Northeast", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c, err := data.GetCountry(tt.query)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for query '%s', but got none", tt.query)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Did not expect error for query '%s', but got: %v", tt.query, err)
+			}
+			if c.Name != tt.expected {
+				t.Errorf("Expected %s, but got %s", tt.expected, c.Name)
+			}
+		})
+	}
+}
+
+func TestRegionQueries(t *testing.T) {
+	data := createRegionTestData()
+
+	_, cumulative, err := data.CompareInflation("US/Northeast", 2015, 1, 2016, 1, 100)
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if !floatsAlmostEqual(cumulative, 3) {
+		t.Errorf("Expected 3%%, but got %f", cumulative)
+	}
+
+	data.Countries[0].Regions[0].Inflation["2016"]["13"] = 1
+	if err := data.Validate(); err == nil {
+		t.Errorf("Expected error for malformed region data, but got none")
+	}
+}
+
+func TestMergeSeriesRegion(t *testing.T) {
+	data := createRegionTestData()
+
+	report, err := data.MergeSeries(ImportedSeries{
+		Area:   "US/0400",
+		Name:   "West",
+		Series: Series{{Period{2016, 1}, 104}},
+	})
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if !report.Created || len(data.Countries[0].Regions) != 2 || len(data.Countries) != 2 {
+		t.Errorf("Expected a new region of the US, but got %+v", report)
+	}
+	if level, err := data.YearInflation("US/West", 2016, 1); err != nil || level != 104 {
+		t.Errorf("Expected 104 for the new region, but got %f (%v)", level, err)
+	}
+
+	if _, err := data.MergeSeries(ImportedSeries{Area: "XX/0100", Series: Series{{Period{2016, 1}, 1}}}); err == nil {
+		t.Errorf("Expected error for region of an unknown country, but got none")
+	}
+
+	clone := data.Clone()
+	clone.Countries[0].Regions[0].Inflation["2016"]["01"] = 1
+	if data.Countries[0].Regions[0].Inflation["2016"]["01"] != 103 {
+		t.Errorf("Expected Clone to copy regions")
+	}
+}
+
+func TestBLSArea(t *testing.T) {
+	tests := []struct {
+		seriesID string
+		expected string
+	}{
+		{BLSHeadline, ""},
+		{"CUUR0100SA0", "0100"},
+		{"CUURS11ASA0", "S11A"},
+		{"APU0000708111", ""},
+	}
+	for _, tt := range tests {
+		if area := blsArea(tt.seriesID); area != tt.expected {
+			t.Errorf("%s: expected '%s', but got '%s'", tt.seriesID, tt.expected, area)
+		}
+	}
+}
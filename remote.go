@@ -0,0 +1,192 @@
+// inflation/remote.go
+package inflation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Defaults for loading inflation data from a URL.
+const (
+	DefaultTimeout     = 30 * time.Second
+	DefaultRetries     = 2
+	DefaultBackoff     = 500 * time.Millisecond
+	DefaultMaxBodySize = 32 << 20 // 32 MiB
+)
+
+// ErrBodyTooLarge is returned when a remote inflation list exceeds the maximum body size.
+var ErrBodyTooLarge = errors.New("inflation data exceeds the maximum body size")
+
+// HTTPError is returned when a remote inflation list cannot be fetched
+// because the server answered with an unexpected status.
+type HTTPError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("failed to fetch inflation data from %s: %s", e.URL, e.Status)
+}
+
+// LoadOption configures how inflation data is loaded from a URL.
+type LoadOption func(*loadConfig)
+
+// loadConfig holds the settings for loading remote inflation data.
+type loadConfig struct {
+	client      *http.Client
+	timeout     time.Duration
+	retries     int
+	backoff     time.Duration
+	maxBodySize int64
+	cache       *Cache
+}
+
+func newLoadConfig(opts []LoadOption) *loadConfig {
+	cfg := &loadConfig{
+		client:      http.DefaultClient,
+		timeout:     DefaultTimeout,
+		retries:     DefaultRetries,
+		backoff:     DefaultBackoff,
+		maxBodySize: DefaultMaxBodySize,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithHTTPClient sets the client used for requests. The default is http.DefaultClient.
+func WithHTTPClient(client *http.Client) LoadOption {
+	return func(cfg *loadConfig) {
+		if client != nil {
+			cfg.client = client
+		}
+	}
+}
+
+// WithTimeout limits the duration of each request attempt, including reading
+// the body. Zero disables the limit.
+func WithTimeout(timeout time.Duration) LoadOption {
+	return func(cfg *loadConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithRetries sets how often a failed request is retried. Retries happen on
+// network errors, 429 and 5xx responses, waiting backoff before the first
+// retry and doubling it before every further one.
+func WithRetries(retries int, backoff time.Duration) LoadOption {
+	return func(cfg *loadConfig) {
+		cfg.retries = max(retries, 0)
+		cfg.backoff = backoff
+	}
+}
+
+// WithMaxBodySize limits the size of a downloaded inflation list. A size of
+// zero or less removes the limit.
+func WithMaxBodySize(size int64) LoadOption {
+	return func(cfg *loadConfig) {
+		cfg.maxBodySize = size
+	}
+}
+
+// WithCache fetches URLs through the given cache, see Cache.
+func WithCache(cache *Cache) LoadOption {
+	return func(cfg *loadConfig) {
+		cfg.cache = cache
+	}
+}
+
+// response is a fully read HTTP response.
+type response struct {
+	statusCode int
+	status     string
+	header     http.Header
+	body       []byte
+}
+
+// fetch downloads the body stored at url, through the cache if one is configured.
+func (cfg *loadConfig) fetch(ctx context.Context, url string) ([]byte, error) {
+	if cfg.cache != nil {
+		return cfg.cache.fetch(ctx, url, cfg)
+	}
+
+	resp, err := cfg.get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.statusCode != http.StatusOK {
+		return nil, &HTTPError{URL: url, StatusCode: resp.statusCode, Status: resp.status}
+	}
+	return resp.body, nil
+}
+
+// get sends a GET request with the given headers, retrying on transient failures.
+// Responses with any status are returned; only the last one is kept when retrying.
+func (cfg *loadConfig) get(ctx context.Context, url string, header http.Header) (*response, error) {
+	backoff := cfg.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := cfg.attempt(ctx, url, header)
+		retryable := (err != nil && !errors.Is(err, ErrBodyTooLarge)) ||
+			(resp != nil && (resp.statusCode == http.StatusTooManyRequests || resp.statusCode >= 500))
+		if !retryable || attempt >= cfg.retries || ctx.Err() != nil {
+			return resp, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// attempt sends a single request and reads its body.
+func (cfg *loadConfig) attempt(ctx context.Context, url string, header http.Header) (*response, error) {
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := cfg.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var reader io.Reader = resp.Body
+	if cfg.maxBodySize > 0 {
+		reader = io.LimitReader(resp.Body, cfg.maxBodySize+1)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.maxBodySize > 0 && int64(len(body)) > cfg.maxBodySize {
+		return nil, fmt.Errorf("%s: %w (%d bytes)", url, ErrBodyTooLarge, cfg.maxBodySize)
+	}
+
+	return &response{
+		statusCode: resp.StatusCode,
+		status:     resp.Status,
+		header:     resp.Header,
+		body:       body,
+	}, nil
+}
@@ -0,0 +1,146 @@
+// remote_test.go
+package inflation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport counts the requests sent through a custom client.
+type countingTransport struct {
+	requests int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.requests, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestLoadInflationDataContext_Retries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(createTestData())
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	data, err := LoadInflationDataContext(context.Background(), server.URL,
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithRetries(2, time.Millisecond))
+	if err != nil {
+		t.Fatalf("Expected success after retries, but got: %v", err)
+	}
+	if len(data.Countries) != 2 {
+		t.Errorf("Expected 2 countries, got %d", len(data.Countries))
+	}
+	if got := atomic.LoadInt32(&transport.requests); got != 3 {
+		t.Errorf("Expected 3 requests through the custom client, got %d", got)
+	}
+}
+
+func TestLoadInflationDataContext_HTTPError(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		expectedRequests int32
+	}{
+		{"Not found is not retried", http.StatusNotFound, 1},
+		{"Server error is retried", http.StatusBadGateway, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			_, err := LoadInflationDataContext(context.Background(), server.URL, WithRetries(2, time.Millisecond))
+			var httpErr *HTTPError
+			if !errors.As(err, &httpErr) {
+				t.Fatalf("Expected *HTTPError, got: %v", err)
+			}
+			if httpErr.StatusCode != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, httpErr.StatusCode)
+			}
+			if got := atomic.LoadInt32(&requests); got != tt.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tt.expectedRequests, got)
+			}
+		})
+	}
+}
+
+func TestLoadInflationDataContext_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	start := time.Now()
+	_, err := LoadInflationDataContext(context.Background(), server.URL,
+		WithTimeout(50*time.Millisecond), WithRetries(0, 0))
+	if err == nil {
+		t.Fatalf("Expected timeout error, but got none")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the request to be aborted by the timeout, took %s", elapsed)
+	}
+}
+
+func TestLoadInflationDataContext_Canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := LoadInflationDataContext(ctx, server.URL, WithRetries(5, time.Hour))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+}
+
+func TestLoadInflationDataContext_MaxBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(createTestData())
+	}))
+	defer server.Close()
+
+	_, err := LoadInflationDataContext(context.Background(), server.URL, WithMaxBodySize(64))
+	if !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Expected ErrBodyTooLarge, got: %v", err)
+	}
+
+	loader := &Loader{}
+	err = loader.LoadDataContext(context.Background(), server.URL, WithMaxBodySize(1<<20))
+	if err != nil {
+		t.Errorf("Did not expect error with a sufficient body size, but got: %v", err)
+	}
+
+	err = loader.LoadDataContext(context.Background(), server.URL, WithMaxBodySize(0))
+	if err != nil {
+		t.Errorf("Did not expect error without a body size limit, but got: %v", err)
+	}
+}
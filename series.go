@@ -0,0 +1,218 @@
+// inflation/series.go
+package inflation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Period identifies a single month of a series.
+type Period struct {
+	Year  int `json:"year"`
+	Month int `json:"month"` // 1-12
+}
+
+// ParsePeriod parses a period in "YYYY-MM" format.
+func ParsePeriod(s string) (Period, error) {
+	if len(s) != 7 || s[4] != '-' {
+		return Period{}, fmt.Errorf("invalid period format: %s", s)
+	}
+	year, err := strconv.Atoi(s[:4])
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid year in period: %v", err)
+	}
+	month, err := strconv.Atoi(s[5:])
+	if err != nil || month < 1 || month > 12 {
+		return Period{}, fmt.Errorf("invalid month in period: %s", s)
+	}
+	return Period{Year: year, Month: month}, nil
+}
+
+// String formats the period as "YYYY-MM", or as "YYYY" for a whole year (month 0).
+func (p Period) String() string {
+	if p.Month == 0 {
+		return fmt.Sprintf("%04d", p.Year)
+	}
+	return fmt.Sprintf("%04d-%02d", p.Year, p.Month)
+}
+
+// Before reports whether p is earlier than q.
+func (p Period) Before(q Period) bool {
+	return p.ordinal() < q.ordinal()
+}
+
+// AddMonths returns the period n months after p (or before, if n is negative).
+func (p Period) AddMonths(n int) Period {
+	return periodFromOrdinal(p.ordinal() + n)
+}
+
+// MonthsUntil returns the number of months from p to q.
+func (p Period) MonthsUntil(q Period) int {
+	return q.ordinal() - p.ordinal()
+}
+
+// ordinal numbers months consecutively so periods can be compared and subtracted.
+func (p Period) ordinal() int {
+	return p.Year*12 + p.Month - 1
+}
+
+func periodFromOrdinal(n int) Period {
+	year, month := n/12, n%12
+	if month < 0 {
+		year, month = year-1, month+12
+	}
+	return Period{Year: year, Month: month + 1}
+}
+
+// Observation is a single index value of a series.
+type Observation struct {
+	Period Period  `json:"period"`
+	Value  float64 `json:"value"`
+}
+
+// Series is a list of observations sorted by period, without duplicates.
+type Series []Observation
+
+// NewSeries builds a sorted Series from the Year -> Month -> Value map used in
+// the JSON format. It fails on keys that are not valid years or months.
+func NewSeries(values map[string]map[string]float64) (Series, error) {
+	var s Series
+	for yearStr, months := range values {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid year '%s': %v", yearStr, err)
+		}
+		for monthStr, value := range months {
+			month, err := strconv.Atoi(monthStr)
+			if err != nil || month < 1 || month > 12 {
+				return nil, fmt.Errorf("invalid month '%s' in year %d", monthStr, year)
+			}
+			s = append(s, Observation{Period: Period{Year: year, Month: month}, Value: value})
+		}
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Period.Before(s[j].Period) })
+	for i := 1; i < len(s); i++ {
+		if s[i].Period == s[i-1].Period {
+			return nil, fmt.Errorf("duplicate value for %s", s[i].Period)
+		}
+	}
+	return s, nil
+}
+
+// Map converts the series back to the Year -> Month -> Value map used in the JSON format.
+func (s Series) Map() map[string]map[string]float64 {
+	values := make(map[string]map[string]float64)
+	for _, o := range s {
+		yearStr := fmt.Sprintf("%d", o.Period.Year)
+		if _, exists := values[yearStr]; !exists {
+			values[yearStr] = make(map[string]float64)
+		}
+		values[yearStr][fmt.Sprintf("%02d", o.Period.Month)] = o.Value
+	}
+	return values
+}
+
+// Len returns the number of observations.
+func (s Series) Len() int {
+	return len(s)
+}
+
+// search returns the position of the first observation not before p.
+func (s Series) search(p Period) int {
+	return sort.Search(len(s), func(i int) bool { return !s[i].Period.Before(p) })
+}
+
+// Lookup returns the value for a period using binary search.
+func (s Series) Lookup(p Period) (float64, bool) {
+	i := s.search(p)
+	if i < len(s) && s[i].Period == p {
+		return s[i].Value, true
+	}
+	return 0, false
+}
+
+// Range returns the observations between from and to, both inclusive.
+// The result shares its backing array with s.
+func (s Series) Range(from, to Period) Series {
+	if to.Before(from) {
+		return nil
+	}
+	return s[s.search(from):s.search(to.AddMonths(1))]
+}
+
+// Year returns the observations of a single calendar year.
+func (s Series) Year(year int) Series {
+	return s.Range(Period{Year: year, Month: 1}, Period{Year: year, Month: 12})
+}
+
+// Years returns the distinct years of the series in ascending order.
+func (s Series) Years() []int {
+	years := []int{}
+	for _, o := range s {
+		if len(years) == 0 || years[len(years)-1] != o.Period.Year {
+			years = append(years, o.Period.Year)
+		}
+	}
+	return years
+}
+
+// First returns the earliest observation.
+func (s Series) First() (Observation, bool) {
+	if len(s) == 0 {
+		return Observation{}, false
+	}
+	return s[0], true
+}
+
+// Last returns the latest observation.
+func (s Series) Last() (Observation, bool) {
+	if len(s) == 0 {
+		return Observation{}, false
+	}
+	return s[len(s)-1], true
+}
+
+// Each calls fn for every observation in order until fn returns false.
+func (s Series) Each(fn func(Observation) bool) {
+	for _, o := range s {
+		if !fn(o) {
+			return
+		}
+	}
+}
+
+// Average returns the mean value of the observations.
+func (s Series) Average() (float64, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, o := range s {
+		sum += o.Value
+	}
+	return sum / float64(len(s)), true
+}
+
+// Series returns the country's index values as a sorted Series.
+func (c *Country) Series() (Series, error) {
+	s, err := NewSeries(c.Inflation)
+	if err != nil {
+		return nil, fmt.Errorf("country '%s': %v", c.Name, err)
+	}
+	return s, nil
+}
+
+// SetSeries replaces the country's index values with the given series.
+func (c *Country) SetSeries(s Series) {
+	c.Inflation = s.Map()
+}
+
+// Scale returns a copy of the series with every value multiplied by factor.
+func (s Series) Scale(factor float64) Series {
+	scaled := make(Series, len(s))
+	for i, o := range s {
+		scaled[i] = Observation{Period: o.Period, Value: o.Value * factor}
+	}
+	return scaled
+}
@@ -0,0 +1,148 @@
+// series_test.go
+package inflation
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestNewSeries(t *testing.T) {
+	data := createTestData()
+	country, err := data.GetCountry("US")
+	if err != nil {
+		t.Fatalf("Did not expect error for country 'US', but got: %v", err)
+	}
+
+	s, err := country.Series()
+	if err != nil {
+		t.Fatalf("Did not expect error building series, but got: %v", err)
+	}
+
+	if s.Len() != 36 {
+		t.Errorf("Expected 36 observations, got %d", s.Len())
+	}
+	for i := 1; i < s.Len(); i++ {
+		if !s[i-1].Period.Before(s[i].Period) {
+			t.Errorf("Series is not sorted: %s before %s", s[i-1].Period, s[i].Period)
+		}
+	}
+
+	first, _ := s.First()
+	last, _ := s.Last()
+	if first.Period != (Period{Year: 2015, Month: 1}) || last.Period != (Period{Year: 2018, Month: 12}) {
+		t.Errorf("Expected series from 2015-01 to 2018-12, got %s to %s", first.Period, last.Period)
+	}
+
+	// Converting back must reproduce the JSON shape
+	originalBytes, _ := json.Marshal(country.Inflation)
+	mappedBytes, _ := json.Marshal(s.Map())
+	if string(originalBytes) != string(mappedBytes) {
+		t.Errorf("Series map does not match original.\nOriginal: %s\nMapped: %s", string(originalBytes), string(mappedBytes))
+	}
+}
+
+func TestNewSeries_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]map[string]float64
+	}{
+		{"Invalid year", map[string]map[string]float64{"20x5": {"01": 1}}},
+		{"Invalid month", map[string]map[string]float64{"2015": {"13": 1}}},
+		{"Non-numeric month", map[string]map[string]float64{"2015": {"Jan": 1}}},
+		{"Duplicate month", map[string]map[string]float64{"2015": {"01": 1, "1": 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSeries(tt.values)
+			if err == nil {
+				t.Errorf("Expected error for %v, but got none", tt.values)
+			}
+		})
+	}
+}
+
+func TestSeriesLookupAndRange(t *testing.T) {
+	data := createTestData()
+	country, _ := data.GetCountry("US")
+	s, _ := country.Series()
+
+	value, ok := s.Lookup(Period{Year: 2016, Month: 3})
+	if !ok || !floatsAlmostEqual(value, 0.35) {
+		t.Errorf("Expected 0.35 for 2016-03, got %.6f (found=%v)", value, ok)
+	}
+	if _, ok := s.Lookup(Period{Year: 2017, Month: 3}); ok {
+		t.Errorf("Did not expect a value for 2017-03")
+	}
+
+	tests := []struct {
+		from     Period
+		to       Period
+		expected int
+	}{
+		{Period{2015, 11}, Period{2016, 2}, 4},
+		{Period{2016, 6}, Period{2018, 3}, 10}, // 2017 is missing
+		{Period{2010, 1}, Period{2015, 1}, 1},
+		{Period{2019, 1}, Period{2020, 1}, 0},
+		{Period{2016, 2}, Period{2015, 11}, 0},
+	}
+	for _, tt := range tests {
+		r := s.Range(tt.from, tt.to)
+		if r.Len() != tt.expected {
+			t.Errorf("Range(%s, %s): expected %d observations, got %d", tt.from, tt.to, tt.expected, r.Len())
+		}
+	}
+
+	years := s.Years()
+	if len(years) != 3 || years[0] != 2015 || years[1] != 2016 || years[2] != 2018 {
+		t.Errorf("Expected years [2015 2016 2018], got %v", years)
+	}
+
+	count := 0
+	s.Each(func(o Observation) bool {
+		count++
+		return o.Period.Year < 2016
+	})
+	if count != 13 {
+		t.Errorf("Expected Each to stop after 13 observations, got %d", count)
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	p, err := ParsePeriod("2015-03")
+	if err != nil {
+		t.Fatalf("Did not expect error parsing '2015-03', but got: %v", err)
+	}
+	if p != (Period{Year: 2015, Month: 3}) {
+		t.Errorf("Expected 2015-03, got %s", p)
+	}
+	if got := p.AddMonths(-3); got != (Period{Year: 2014, Month: 12}) {
+		t.Errorf("Expected 2014-12, got %s", got)
+	}
+	if got := p.AddMonths(10); got != (Period{Year: 2016, Month: 1}) {
+		t.Errorf("Expected 2016-01, got %s", got)
+	}
+	if got := p.MonthsUntil(Period{Year: 2024, Month: 7}); got != 112 {
+		t.Errorf("Expected 112 months, got %d", got)
+	}
+
+	for _, invalid := range []string{"2015", "2015-13", "2015/03", "15-03-01"} {
+		if _, err := ParsePeriod(invalid); err == nil {
+			t.Errorf("Expected error parsing '%s', but got none", invalid)
+		}
+	}
+}
+
+func TestLoadInflationData_Malformed(t *testing.T) {
+	path := t.TempDir() + "/malformed.json"
+	err := os.WriteFile(path, []byte(`{"countries":[{"name":"Test","code":"TT","inflation":{"2015":{"1x":1}}}]}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	_, err = LoadInflationData(path, false)
+	if err == nil {
+		t.Errorf("Expected error when loading malformed inflation keys, but got none")
+	}
+}
@@ -0,0 +1,202 @@
+// inflation/span.go
+package inflation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Span is a range of consecutive months whose index level is the average of
+// its months: a month, a quarter (2024-Q3), a half year (2024-H1), a calendar
+// year (2024) or a fiscal year (FY2024).
+type Span struct {
+	From Period `json:"from"` // First month
+	To   Period `json:"to"`   // Last month, inclusive
+}
+
+// MonthSpan returns the span of a single month.
+func MonthSpan(year, month int) Span {
+	p := Period{Year: year, Month: month}
+	return Span{From: p, To: p}
+}
+
+// YearSpan returns the span of a calendar year.
+func YearSpan(year int) Span {
+	return Span{From: Period{Year: year, Month: 1}, To: Period{Year: year, Month: 12}}
+}
+
+// QuarterSpan returns the span of a quarter (1 to 4) of a year.
+func QuarterSpan(year, quarter int) (Span, error) {
+	if quarter < 1 || quarter > 4 {
+		return Span{}, fmt.Errorf("invalid quarter: %d", quarter)
+	}
+	from := Period{Year: year, Month: 3*quarter - 2}
+	return Span{From: from, To: from.AddMonths(2)}, nil
+}
+
+// HalfSpan returns the span of a half (1 or 2) of a year.
+func HalfSpan(year, half int) (Span, error) {
+	if half < 1 || half > 2 {
+		return Span{}, fmt.Errorf("invalid half: %d", half)
+	}
+	from := Period{Year: year, Month: 6*half - 5}
+	return Span{From: from, To: from.AddMonths(5)}, nil
+}
+
+// FiscalYearSpan returns the span of a fiscal year that starts in startMonth
+// (1 to 12). Fiscal years are named by the calendar year they end in, as US
+// federal and Australian fiscal years are: with startMonth 7, FY2024 runs from
+// 2023-07 to 2024-06. With startMonth 1 it is the calendar year.
+func FiscalYearSpan(year, startMonth int) (Span, error) {
+	if startMonth < 1 || startMonth > 12 {
+		return Span{}, fmt.Errorf("invalid fiscal year start month: %d", startMonth)
+	}
+	to := Period{Year: year, Month: 12}
+	if startMonth > 1 {
+		to = Period{Year: year, Month: startMonth - 1}
+	}
+	return Span{From: to.AddMonths(-11), To: to}, nil
+}
+
+// ParseSpan parses a span: YYYY, YYYY-MM, YYYY-Qn, YYYY-Hn or FYYYYY for a
+// fiscal year starting in fiscalStart (the calendar year if 0 or 1), see
+// FiscalYearSpan.
+func ParseSpan(s string, fiscalStart int) (Span, error) {
+	if fiscalStart == 0 {
+		fiscalStart = 1
+	}
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	if strings.HasPrefix(upper, "FY") {
+		year, err := strconv.Atoi(upper[2:])
+		if err != nil || len(upper) != 6 {
+			return Span{}, fmt.Errorf("invalid fiscal year '%s' (use FYYYYY, e.g. FY2024)", s)
+		}
+		return FiscalYearSpan(year, fiscalStart)
+	}
+	if len(upper) == 4 {
+		year, err := strconv.Atoi(upper)
+		if err != nil {
+			return Span{}, fmt.Errorf("invalid year '%s'", s)
+		}
+		return YearSpan(year), nil
+	}
+	if len(upper) == 7 && (upper[5] == 'Q' || upper[5] == 'H') && upper[4] == '-' {
+		year, err := strconv.Atoi(upper[:4])
+		if err != nil {
+			return Span{}, fmt.Errorf("invalid year in '%s'", s)
+		}
+		n, err := strconv.Atoi(upper[6:])
+		if err != nil {
+			return Span{}, fmt.Errorf("invalid span '%s'", s)
+		}
+		if upper[5] == 'Q' {
+			return QuarterSpan(year, n)
+		}
+		return HalfSpan(year, n)
+	}
+	p, err := ParsePeriod(upper)
+	if err != nil {
+		return Span{}, fmt.Errorf("invalid span '%s' (use YYYY, YYYY-MM, YYYY-Qn, YYYY-Hn or FYYYYY)", s)
+	}
+	return MonthSpan(p.Year, p.Month), nil
+}
+
+// Months returns the number of months of the span.
+func (s Span) Months() int {
+	return s.From.MonthsUntil(s.To) + 1
+}
+
+// AddMonths returns the span shifted by n months.
+func (s Span) AddMonths(n int) Span {
+	return Span{From: s.From.AddMonths(n), To: s.To.AddMonths(n)}
+}
+
+// IsYear reports whether the span is a calendar year.
+func (s Span) IsYear() bool {
+	return s == YearSpan(s.From.Year)
+}
+
+func (s Span) String() string {
+	n := s.Months()
+	switch {
+	case n == 1:
+		return s.From.String()
+	case s.IsYear():
+		return strconv.Itoa(s.From.Year)
+	case n == 3 && s.From.Month%3 == 1:
+		return fmt.Sprintf("%d-Q%d", s.From.Year, s.From.Month/3+1)
+	case n == 6 && s.From.Month%6 == 1:
+		return fmt.Sprintf("%d-H%d", s.From.Year, s.From.Month/6+1)
+	case n == 12:
+		return fmt.Sprintf("FY%d", s.To.Year)
+	}
+	return s.From.String() + ".." + s.To.String()
+}
+
+// spanOf returns the span of a year (month 0) or month.
+func spanOf(year, month int) Span {
+	if month == 0 {
+		return YearSpan(year)
+	}
+	return MonthSpan(year, month)
+}
+
+// SpanIndexLevel returns the index level of a span, the average of its months,
+// see IndexLevel. A month and a calendar year give the same level as
+// IndexLevel; other spans have Span set to their name, Period set to their
+// first month and Average set. Like annual averages, spans with missing
+// months are refused unless Data.PartialYears is set.
+func (d *Data) SpanIndexLevel(country string, span Span, opts ...QueryOption) (IndexValue, error) {
+	_, s, err := d.countrySeries(country, opts...)
+	if err != nil {
+		return IndexValue{}, err
+	}
+	return s.spanValue(country, span)
+}
+
+// CompareSpans adjusts a price for inflation between the index levels of two
+// spans, see Compare and SpanIndexLevel. A span is positioned at the middle
+// of its months, as a calendar year is.
+func (d *Data) CompareSpans(country string, from, to Span, price float64, opts ...QueryOption) (Comparison, error) {
+	c, s, err := d.countrySeries(country, opts...)
+	if err != nil {
+		return Comparison{}, err
+	}
+	fromValue, err := s.spanValue(country, from)
+	if err != nil {
+		return Comparison{}, err
+	}
+	toValue, err := s.spanValue(country, to)
+	if err != nil {
+		return Comparison{}, err
+	}
+	result, err := newComparison(c, newQuery(opts).series, fromValue, toValue, price)
+	result.Projection = s.projectionName(fromValue, toValue)
+	return result, err
+}
+
+// spanValue returns the average index level of the months of a span.
+func (s indexSeries) spanValue(country string, span Span) (IndexValue, error) {
+	if span.To.Before(span.From) {
+		return IndexValue{}, fmt.Errorf("invalid span: %s is before %s", span.To, span.From)
+	}
+	if span.Months() == 1 {
+		return s.indexValue(country, span.From.Year, span.From.Month)
+	}
+	if span.IsYear() {
+		return s.indexValue(country, span.From.Year, 0)
+	}
+
+	months := s.Range(span.From, span.To)
+	if months.Len() == 0 {
+		return IndexValue{}, fmt.Errorf("inflation data for %s not found for country '%s'", span, country)
+	}
+	if months.Len() < span.Months() && !s.partialYears {
+		return IndexValue{}, &IncompleteError{Country: country, Span: span, Months: months.Len()}
+	}
+	average, _ := months.Average()
+	v := IndexValue{Period: span.From, Span: span.String(), Value: average, Average: true, Months: months.Len(), through: span.To}
+	s.annotate(&v, months)
+	return v, nil
+}
@@ -0,0 +1,112 @@
+// span_test.go
+package inflation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSpan(t *testing.T) {
+	tests := []struct {
+		input       string
+		fiscalStart int
+		from, to    Period
+		name        string
+		wantErr     bool
+	}{
+		{"2024", 0, Period{2024, 1}, Period{2024, 12}, "2024", false},
+		{"2024-07", 0, Period{2024, 7}, Period{2024, 7}, "2024-07", false},
+		{"2024-Q3", 0, Period{2024, 7}, Period{2024, 9}, "2024-Q3", false},
+		{"2024-q1", 0, Period{2024, 1}, Period{2024, 3}, "2024-Q1", false},
+		{"2024-H2", 0, Period{2024, 7}, Period{2024, 12}, "2024-H2", false},
+		{"FY2024", 4, Period{2023, 4}, Period{2024, 3}, "FY2024", false},
+		{"FY2024", 7, Period{2023, 7}, Period{2024, 6}, "FY2024", false},
+		{"FY2024", 0, Period{2024, 1}, Period{2024, 12}, "2024", false},
+		{"2024-Q5", 0, Period{}, Period{}, "", true},
+		{"2024-H3", 0, Period{}, Period{}, "", true},
+		{"FY24", 4, Period{}, Period{}, "", true},
+		{"FY2024", 13, Period{}, Period{}, "", true},
+		{"2024-13", 0, Period{}, Period{}, "", true},
+		{"24", 0, Period{}, Period{}, "", true},
+	}
+	for _, tt := range tests {
+		span, err := ParseSpan(tt.input, tt.fiscalStart)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for '%s', but got none", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Did not expect error for '%s', but got: %v", tt.input, err)
+			continue
+		}
+		if span.From != tt.from || span.To != tt.to || span.String() != tt.name {
+			t.Errorf("Expected %s (%s to %s) for '%s', but got %s (%s to %s)", tt.name, tt.from, tt.to, tt.input, span, span.From, span.To)
+		}
+	}
+}
+
+func TestSpanIndexLevel(t *testing.T) {
+	data := createTestData()
+
+	// 2015-Q1 of the US is 0.1, 0.2 and 0.3
+	q1, _ := QuarterSpan(2015, 1)
+	level, err := data.SpanIndexLevel("US", q1)
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if !floatsAlmostEqual(level.Value, 0.2) || level.Span != "2015-Q1" || level.Months != 3 || !level.Average {
+		t.Errorf("Expected the average of 2015-Q1, but got %+v", level)
+	}
+
+	// A calendar year and a month give the same level as IndexLevel
+	for _, span := range []Span{YearSpan(2016), MonthSpan(2016, 3)} {
+		got, err := data.SpanIndexLevel("US", span)
+		month := span.From.Month
+		if span.IsYear() {
+			month = 0
+		}
+		expected, _ := data.IndexLevel("US", span.From.Year, month)
+		if err != nil || got.Value != expected.Value || got.Period != expected.Period || got.Span != "" {
+			t.Errorf("Expected %+v for %s, but got %+v, %v", expected, span, got, err)
+		}
+	}
+
+	// FY2016 starting in July spans 2015-07 to 2016-06
+	fy, _ := FiscalYearSpan(2016, 7)
+	if _, err := data.SpanIndexLevel("US", fy); err != nil {
+		t.Errorf("Did not expect error for %s, but got: %v", fy, err)
+	}
+	fy, _ = FiscalYearSpan(2017, 7)
+	if _, err := data.SpanIndexLevel("US", fy); err == nil || !strings.Contains(err.Error(), "incomplete") {
+		t.Errorf("Expected error for the incomplete %s, but got: %v", fy, err)
+	}
+	data.PartialYears = true
+	if level, err := data.SpanIndexLevel("US", fy); err != nil || level.Months != 6 {
+		t.Errorf("Expected 6 months of %s with PartialYears, but got %+v, %v", fy, level, err)
+	}
+	data.PartialYears = false
+
+	// 2015-Q1 to 2015-Q4 is three quarters apart, from the middle of each quarter
+	q4, _ := QuarterSpan(2015, 4)
+	result, err := data.CompareSpans("US", q1, q4, 100)
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if !floatsAlmostEqual(result.Years, 0.75) || !floatsAlmostEqual(result.Price, 100*0.2/0.2) {
+		t.Errorf("Unexpected comparison of quarters: %+v", result)
+	}
+
+	rate, err := data.SpanYearOverYear("US", Span{From: Period{2016, 1}, To: Period{2016, 3}})
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	q1of2016, _ := data.SpanIndexLevel("US", Span{From: Period{2016, 1}, To: Period{2016, 3}})
+	if !floatsAlmostEqual(rate, (q1of2016.Value/0.2-1)*100) {
+		t.Errorf("Expected the change over 2015-Q1, but got %.4f", rate)
+	}
+	if _, err := data.SpanOverSpan("US", q1); err == nil {
+		t.Errorf("Expected error for the change over 2014-Q4 without data, but got none")
+	}
+}
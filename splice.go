@@ -0,0 +1,101 @@
+// inflation/splice.go
+package inflation
+
+import (
+	"fmt"
+)
+
+// SpliceInfo records where a country's series was joined with another series.
+type SpliceInfo struct {
+	At          Period  `json:"at"`           // First period after the join: the first of the country's series when extended backward
+	OverlapFrom Period  `json:"overlap_from"` // First period used to link the series
+	OverlapTo   Period  `json:"overlap_to"`   // Last period used to link the series
+	Ratio       float64 `json:"ratio"`        // Factor applied to the other series
+	Source      string  `json:"source,omitempty"`
+}
+
+// Splice joins other onto base by ratio splicing: other is rescaled by the
+// ratio of the average values of both series over their common periods between
+// overlapFrom and overlapTo (all common periods if both are zero), and used for
+// the periods before the first and after the last observation of base. Values
+// of base are never changed, so the result stays on the base of base.
+func Splice(base, other Series, overlapFrom, overlapTo Period) (Series, []SpliceInfo, error) {
+	first, ok := base.First()
+	if !ok {
+		return nil, nil, fmt.Errorf("cannot splice onto an empty series")
+	}
+	last, _ := base.Last()
+	if overlapFrom == (Period{}) && overlapTo == (Period{}) {
+		overlapFrom, overlapTo = first.Period, last.Period
+	}
+
+	var baseSum, otherSum float64
+	var overlap Series
+	for _, o := range other.Range(overlapFrom, overlapTo) {
+		value, ok := base.Lookup(o.Period)
+		if ok {
+			baseSum += value
+			otherSum += o.Value
+			overlap = append(overlap, o)
+		}
+	}
+	if overlap.Len() == 0 {
+		return nil, nil, fmt.Errorf("series have no common periods between %s and %s", overlapFrom, overlapTo)
+	}
+	if otherSum == 0 {
+		return nil, nil, fmt.Errorf("cannot splice a series with zero values over the overlap")
+	}
+	ratio := baseSum / otherSum
+
+	overlapFirst, _ := overlap.First()
+	overlapLast, _ := overlap.Last()
+	info := SpliceInfo{OverlapFrom: overlapFirst.Period, OverlapTo: overlapLast.Period, Ratio: ratio}
+
+	before := other[:other.search(first.Period)].Scale(ratio)
+	after := other[other.search(last.Period.AddMonths(1)):].Scale(ratio)
+
+	var splices []SpliceInfo
+	joined := make(Series, 0, before.Len()+base.Len()+after.Len())
+	if before.Len() > 0 {
+		s := info
+		s.At = first.Period
+		splices = append(splices, s)
+		joined = append(joined, before...)
+	}
+	joined = append(joined, base...)
+	if after.Len() > 0 {
+		s := info
+		s.At = after[0].Period
+		splices = append(splices, s)
+		joined = append(joined, after...)
+	}
+	if len(splices) == 0 {
+		return nil, nil, fmt.Errorf("the other series adds no periods outside %s to %s", first.Period, last.Period)
+	}
+	return joined, splices, nil
+}
+
+// Splice joins other onto the series of a country, see Splice, and records
+// the splice points in Country.Splices. This extends the country's history
+// (e.g. older CPI publications with a different base) so comparisons can span it.
+// Only the stored values are joined; months filled by Data.Gaps stay missing.
+func (d *Data) Splice(country string, other Series, overlapFrom, overlapTo Period, source string) ([]SpliceInfo, error) {
+	c, err := d.GetCountry(country)
+	if err != nil {
+		return nil, err
+	}
+	base, err := c.SeriesFor(Headline)
+	if err != nil {
+		return nil, err
+	}
+	joined, splices, err := Splice(base, other, overlapFrom, overlapTo)
+	if err != nil {
+		return nil, fmt.Errorf("cannot splice '%s': %v", c.Name, err)
+	}
+	for i := range splices {
+		splices[i].Source = source
+	}
+	c.SetSeries(joined)
+	c.Splices = append(c.Splices, splices...)
+	return splices, nil
+}
@@ -0,0 +1,101 @@
+// splice_test.go
+package inflation
+
+import (
+	"testing"
+)
+
+func TestSplice(t *testing.T) {
+	base := Series{
+		{Period{2020, 1}, 100},
+		{Period{2020, 2}, 101},
+		{Period{2020, 3}, 102},
+	}
+	older := Series{
+		{Period{2019, 11}, 48},
+		{Period{2019, 12}, 49},
+		{Period{2020, 1}, 50},
+		{Period{2020, 2}, 50.5},
+	}
+
+	joined, splices, err := Splice(base, older, Period{}, Period{})
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if joined.Len() != 5 {
+		t.Fatalf("Expected 5 observations, but got %d", joined.Len())
+	}
+	if value, _ := joined.Lookup(Period{2019, 12}); !floatsAlmostEqual(value, 98) {
+		t.Errorf("Expected 2019-12 to be 98, but got %f", value)
+	}
+	if value, _ := joined.Lookup(Period{2020, 2}); value != 101 {
+		t.Errorf("Expected base values to be kept, but got %f for 2020-02", value)
+	}
+	if len(splices) != 1 {
+		t.Fatalf("Expected 1 splice point, but got %d", len(splices))
+	}
+	s := splices[0]
+	if s.At != (Period{2020, 1}) || s.OverlapFrom != (Period{2020, 1}) || s.OverlapTo != (Period{2020, 2}) || !floatsAlmostEqual(s.Ratio, 2) {
+		t.Errorf("Unexpected splice point %+v", s)
+	}
+
+	if _, _, err := Splice(base, Series{{Period{2018, 1}, 40}}, Period{}, Period{}); err == nil {
+		t.Errorf("Expected error for series without overlap, but got none")
+	}
+	if _, _, err := Splice(base, base[:2], Period{}, Period{}); err == nil {
+		t.Errorf("Expected error for series adding no periods, but got none")
+	}
+	if _, _, err := Splice(base, older, Period{2019, 1}, Period{2019, 12}); err == nil {
+		t.Errorf("Expected error for overlap outside the base series, but got none")
+	}
+}
+
+func TestDataSplice(t *testing.T) {
+	data := createTestData()
+	older := Series{
+		{Period{2014, 12}, 0.05},
+		{Period{2015, 1}, 0.05},
+	}
+
+	splices, err := data.Splice("US", older, Period{}, Period{}, "archive")
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if len(splices) != 1 || splices[0].Source != "archive" {
+		t.Errorf("Unexpected splice points %+v", splices)
+	}
+	first, _ := data.Countries[0].GetFirstDate()
+	if first != 2014 {
+		t.Errorf("Expected history to start in 2014, but got %d", first)
+	}
+	if len(data.Countries[0].Splices) != 1 {
+		t.Errorf("Expected the splice to be recorded on the country")
+	}
+	if _, _, err := data.CompareInflation("US", 2014, 12, 2018, 0, 100); err != nil {
+		t.Errorf("Did not expect error comparing across the splice, but got: %v", err)
+	}
+
+	if _, err := data.Splice("XX", older, Period{}, Period{}, ""); err == nil {
+		t.Errorf("Expected error for unknown country, but got none")
+	}
+}
+
+func TestDataSpliceGaps(t *testing.T) {
+	data := createTestData()
+	data.Gaps = GapLinear
+	// US has no 2017; filled months must not be stored by splicing
+	older := Series{
+		{Period{2014, 12}, 0.05},
+		{Period{2015, 1}, 0.05},
+	}
+	if _, err := data.Splice("US", older, Period{}, Period{}, ""); err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if _, ok := data.Countries[0].Inflation["2017"]; ok {
+		t.Errorf("Expected 2017 to stay missing, but got %v", data.Countries[0].Inflation["2017"])
+	}
+	data.Gaps = GapError
+	if _, err := data.IndexLevel("US", 2017, 6); err == nil {
+		t.Errorf("Expected error for a missing month after splicing, but got none")
+	}
+}
@@ -0,0 +1,169 @@
+// inflation/subindex.go
+package inflation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Headline is the COICOP code of the all-items index stored in Country.Inflation.
+const Headline = "CP00"
+
+// SubIndex is the index of a category of consumption for a country, e.g. food or energy.
+type SubIndex struct {
+	Name      string                        `json:"name"`
+	Inflation map[string]map[string]float64 `json:"inflation"` // Year -> Month -> Index level, see Series
+}
+
+// Series returns the sub-index values as a sorted Series.
+func (s *SubIndex) Series() (Series, error) {
+	return NewSeries(s.Inflation)
+}
+
+// SetSeries replaces the sub-index values with the given series.
+func (s *SubIndex) SetSeries(series Series) {
+	s.Inflation = series.Map()
+}
+
+// SubIndexNames holds the names of well-known COICOP codes and special aggregates,
+// using the codes of Eurostat's HICP datasets.
+var SubIndexNames = map[string]string{
+	"CP00":           "All-items",
+	"CP01":           "Food and non-alcoholic beverages",
+	"CP02":           "Alcoholic beverages and tobacco",
+	"CP03":           "Clothing and footwear",
+	"CP04":           "Housing, water, electricity, gas and other fuels",
+	"CP05":           "Furnishings, household equipment and routine household maintenance",
+	"CP06":           "Health",
+	"CP07":           "Transport",
+	"CP08":           "Communications",
+	"CP09":           "Recreation and culture",
+	"CP10":           "Education",
+	"CP11":           "Restaurants and hotels",
+	"CP12":           "Miscellaneous goods and services",
+	"FOOD":           "Food including alcohol and tobacco",
+	"NRG":            "Energy",
+	"SERV":           "Services",
+	"GD":             "Goods",
+	"TOT_X_NRG_FOOD": "All-items excluding energy, food, alcohol and tobacco",
+}
+
+// seriesShortNames maps the selectors used in everyday language to COICOP codes.
+var seriesShortNames = map[string]string{
+	"headline": Headline,
+	"all":      Headline,
+	"food":     "FOOD",
+	"energy":   "NRG",
+	"services": "SERV",
+	"goods":    "GD",
+	"housing":  "CP04",
+	"core":     "TOT_X_NRG_FOOD",
+}
+
+// QueryOption configures a query on Data, e.g. YearInflation or CompareInflation.
+type QueryOption func(*query)
+
+type query struct {
+	series     string
+	projection Projection
+}
+
+// WithSeries selects the series a query uses by COICOP code (e.g. CP01),
+// sub-index name or short name (food, energy, services, housing, core).
+// Without it, queries use the headline index.
+func WithSeries(selector string) QueryOption {
+	return func(q *query) {
+		q.series = selector
+	}
+}
+
+// WithProjection extends the series beyond its last observation with a
+// projection model, e.g. TargetRate{Rate: 2}, so that queries can use future
+// dates. Values based on projected months are flagged, see IndexValue.Projected.
+func WithProjection(p Projection) QueryOption {
+	return func(q *query) {
+		q.projection = p
+	}
+}
+
+func newQuery(opts []QueryOption) query {
+	var q query
+	for _, opt := range opts {
+		opt(&q)
+	}
+	return q
+}
+
+// SeriesCode returns the COICOP code a selector refers to, see WithSeries.
+// An empty selector refers to the headline index.
+func (c *Country) SeriesCode(selector string) (string, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return Headline, nil
+	}
+	code := seriesCode(selector)
+	if code == Headline {
+		return Headline, nil
+	}
+	if _, ok := c.SubIndices[code]; ok {
+		return code, nil
+	}
+	for code, sub := range c.SubIndices {
+		if strings.EqualFold(sub.Name, selector) {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("series '%s' not found for country '%s'", selector, c.Name)
+}
+
+// seriesCode converts a COICOP code or short name to the code sub-indices are stored under.
+func seriesCode(selector string) string {
+	if selector == "" {
+		return Headline
+	}
+	if short, ok := seriesShortNames[strings.ToLower(selector)]; ok {
+		return short
+	}
+	return strings.ToUpper(selector)
+}
+
+// SeriesFor returns the values of the series selected by selector, see WithSeries.
+func (c *Country) SeriesFor(selector string) (Series, error) {
+	code, err := c.SeriesCode(selector)
+	if err != nil {
+		return nil, err
+	}
+	if code == Headline {
+		return c.Series()
+	}
+	sub := c.SubIndices[code]
+	s, err := sub.Series()
+	if err != nil {
+		return nil, fmt.Errorf("country '%s' series %s: %v", c.Name, code, err)
+	}
+	return s, nil
+}
+
+// SeriesCodes returns the headline code followed by the codes of the country's sub-indices in order.
+func (c *Country) SeriesCodes() []string {
+	codes := make([]string, 0, len(c.SubIndices))
+	for code := range c.SubIndices {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return append([]string{Headline}, codes...)
+}
+
+// setSubIndex stores the values of a sub-index, creating it if needed.
+func (c *Country) setSubIndex(code string, s Series) {
+	if c.SubIndices == nil {
+		c.SubIndices = make(map[string]SubIndex)
+	}
+	sub := c.SubIndices[code]
+	if sub.Name == "" {
+		sub.Name = SubIndexNames[code]
+	}
+	sub.SetSeries(s)
+	c.SubIndices[code] = sub
+}
@@ -0,0 +1,151 @@
+// subindex_test.go
+package inflation
+
+import (
+	"testing"
+)
+
+// createSubIndexTestData adds food and energy sub-indices to the US test data.
+func createSubIndexTestData() Data {
+	data := createTestData()
+	data.Countries[0].SubIndices = map[string]SubIndex{
+		"FOOD": {
+			Name: "Food including alcohol and tobacco",
+			Inflation: map[string]map[string]float64{
+				"2015": {"01": 100, "06": 102},
+				"2016": {"01": 104, "06": 110},
+			},
+		},
+		"NRG": {
+			Name: "Energy",
+			Inflation: map[string]map[string]float64{
+				"2015": {"01": 100},
+				"2016": {"01": 80},
+			},
+		},
+	}
+	return data
+}
+
+func TestSeriesCode(t *testing.T) {
+	data := createSubIndexTestData()
+	us := &data.Countries[0]
+
+	tests := []struct {
+		selector    string
+		expected    string
+		expectError bool
+	}{
+		{"", Headline, false},
+		{"headline", Headline, false},
+		{"cp00", Headline, false},
+		{"FOOD", "FOOD", false},
+		{"food", "FOOD", false},
+		{"energy", "NRG", false},
+		{"Energy", "NRG", false},
+		{"core", "", true},
+		{"CP01", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			code, err := us.SeriesCode(tt.selector)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for selector '%s', but got none", tt.selector)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Did not expect error for selector '%s', but got: %v", tt.selector, err)
+			}
+			if code != tt.expected {
+				t.Errorf("Expected %s, but got %s", tt.expected, code)
+			}
+		})
+	}
+}
+
+func TestYearInflationWithSeries(t *testing.T) {
+	data := createSubIndexTestData()
+
+	level, err := data.YearInflation("US", 2016, 6, WithSeries("food"))
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if level != 110 {
+		t.Errorf("Expected 110, but got %f", level)
+	}
+
+	headline, err := data.YearInflation("US", 2016, 6)
+	if err != nil || !floatsAlmostEqual(headline, 0.35) {
+		t.Errorf("Expected headline 0.35, but got %f (%v)", headline, err)
+	}
+
+	newPrice, cumulative, err := data.CompareInflation("US", 2015, 1, 2016, 1, 100, WithSeries("NRG"))
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if !floatsAlmostEqual(newPrice, 80) || !floatsAlmostEqual(cumulative, -20) {
+		t.Errorf("Expected 80 and -20%%, but got %f and %f", newPrice, cumulative)
+	}
+
+	if _, err := data.YearOverYear("US", 2016, 1, WithSeries("food")); err != nil {
+		t.Errorf("Did not expect error for the food rate, but got: %v", err)
+	}
+	if _, err := data.YearInflation("US", 2016, 1, WithSeries("services")); err == nil {
+		t.Errorf("Expected error for missing sub-index, but got none")
+	}
+	if _, err := data.YearInflation("US", 2016, 3, WithSeries("food")); err == nil {
+		t.Errorf("Expected error for missing month in sub-index, but got none")
+	}
+}
+
+func TestMergeSeriesSubIndex(t *testing.T) {
+	data := createTestData()
+	report, err := data.MergeSeries(ImportedSeries{
+		Area:     "US",
+		SubIndex: "energy",
+		Series:   Series{{Period{2016, 1}, 95}},
+	})
+	if err != nil {
+		t.Fatalf("Did not expect error, but got: %v", err)
+	}
+	if report.Series != "NRG" || len(report.Added) != 1 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	us := data.Countries[0]
+	if us.SubIndices["NRG"].Name != "Energy" {
+		t.Errorf("Expected the sub-index to be named after its code, but got '%s'", us.SubIndices["NRG"].Name)
+	}
+	if level, _ := data.YearInflation("US", 2016, 1); level != 0.15 {
+		t.Errorf("Expected the headline index to be unchanged, but got %f", level)
+	}
+
+	clone := data.Clone()
+	clone.Countries[0].SubIndices["NRG"].Inflation["2016"]["01"] = 1
+	if data.Countries[0].SubIndices["NRG"].Inflation["2016"]["01"] != 95 {
+		t.Errorf("Expected Clone to copy sub-indices")
+	}
+}
+
+func TestImportedSubIndexCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"ECB headline", ecbSubIndex("000000"), ""},
+		{"ECB division", ecbSubIndex("010000"), "CP01"},
+		{"ECB class", ecbSubIndex("011100"), "CP0111"},
+		{"ECB energy", ecbSubIndex("NRGY00"), "NRG"},
+		{"BLS headline", blsSubIndex(BLSHeadline), ""},
+		{"BLS core", blsSubIndex("CUUR0000SA0L1E"), "TOT_X_NRG_FOOD"},
+		{"BLS other", blsSubIndex("CUUR0000SEFV"), "SEFV"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s: expected '%s', but got '%s'", tt.name, tt.expected, tt.got)
+		}
+	}
+}